@@ -1,48 +1,229 @@
 package main
 
 import (
-	"log"
-	"pet1/internal/db"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"pet1/internal/authService"
+	"pet1/internal/config"
 	"pet1/internal/handlers"
+	"pet1/internal/logging"
+	"pet1/internal/metrics"
+	"pet1/internal/password"
+	"pet1/internal/reminder"
+	"pet1/internal/server"
 	"pet1/internal/taskService"
+	"pet1/internal/tracing"
 	"pet1/internal/userService"
+	"pet1/internal/web/admin"
+	"pet1/internal/web/auth"
+	"pet1/internal/web/labels"
 	"pet1/internal/web/tasks"
 	"pet1/internal/web/users"
+	"syscall"
+	"time"
+	// Часовые пояса для фильтров по сроку задач, даже если в образе нет системной базы зон
+	_ "time/tzdata"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 func main() {
-	// Инициализация БД
-	db.InitDB()
+	// Загружаем настройки: значения по умолчанию, файл, окружение, флаги
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		fatal("failed to load config", err)
+	}
 
-	// Инициализация сервисов задач
-	tasksRepo := taskService.NewTaskRepository(db.DB)
-	tasksService := taskService.NewService(tasksRepo)
-	tasksHandler := handlers.NewTaskHandler(tasksService)
+	// Общий JSON-логгер. Уровень хранится в LevelVar, его можно менять через /admin/log-level
+	var logLevel slog.LevelVar
+	level, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		fatal("failed to parse log level", err)
+	}
+	logLevel.Set(level)
+	logger := logging.New(os.Stdout, &logLevel)
+	slog.SetDefault(logger)
+	slog.Info("effective config", "config", cfg.Redacted())
+
+	// Подкоманды управляют схемой и завершаются, сервер не запускается
+	if len(args) > 0 {
+		switch {
+		case cfg.DB.Driver == config.DriverMemory:
+			err = fmt.Errorf("%s is not available with the memory driver", args[0])
+		case args[0] == "migrate":
+			err = runMigrate(cfg.DB, args[1:])
+		case args[0] == "schema-check":
+			err = runSchemaCheck(cfg.DB)
+		default:
+			err = fmt.Errorf("unknown command %q, expected migrate or schema-check", args[0])
+		}
+		if err != nil {
+			fatal(args[0]+" failed", err)
+		}
+		return
+	}
+
+	// Трассировка: глобальный TracerProvider и пропагация W3C traceparent
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("failed to init tracing", err)
+	}
+
+	// Хранилище: база из настроек или память процесса
+	appMetrics := metrics.New()
+	store, err := openStorage(cfg.DB, appMetrics)
+	if err != nil {
+		fatal("failed to open storage", err)
+	}
+
+	// Инициализация хеширования паролей
+	hasher, err := password.NewHasher(cfg.Password.Hasher())
+	if err != nil {
+		fatal("failed to init password hasher", err)
+	}
 
 	// Инициализация сервисов пользователей
-	usersRepo := userService.NewUserRepository(db.DB)
-	usersService := userService.NewService(usersRepo)
+	usersService := userService.NewService(store.users, store.tasks, store.tx, hasher, cfg.Features.PasswordRehash)
 	usersHandler := handlers.NewUserHandler(usersService)
 
+	// Инициализация сервисов задач. Пользователи нужны, чтобы отличить
+	// «у пользователя нет задач» от «пользователя нет»
+	tasksService := taskService.NewService(store.tasks, store.labels, usersService, store.tx, appMetrics, taskService.TreeOptions{
+		MaxDepth:           cfg.Tasks.MaxDepth,
+		AutoCompleteParent: cfg.Tasks.AutoCompleteParent,
+		BlockOpenSubtasks:  cfg.Tasks.BlockOpenSubtasks,
+	})
+	tasksHandler := handlers.NewTaskHandler(tasksService)
+	labelsHandler := handlers.NewLabelHandler(tasksService)
+
+	// Создаём первого админа, если он задан в настройках
+	if cfg.Admin.Email != "" {
+		if err := usersService.EnsureAdmin(context.Background(), cfg.Admin.Email, cfg.Admin.Password); err != nil {
+			fatal("failed to create admin user", err)
+		}
+	}
+
+	// Инициализация аутентификации
+	authConfig := authService.DefaultConfig()
+	authConfig.Secret = []byte(cfg.Auth.JWTSecret)
+	authConfig.AccessTTL = cfg.Auth.AccessTTL
+	authConfig.RefreshTTL = cfg.Auth.RefreshTTL
+	if len(authConfig.Secret) == 0 {
+		// Без секрета генерируем случайный: токены перестанут действовать после рестарта
+		slog.Warn("auth.jwt_secret is not set, using a random secret")
+		authConfig.Secret = make([]byte, 32)
+		if _, err := rand.Read(authConfig.Secret); err != nil {
+			fatal("failed to generate jwt secret", err)
+		}
+	}
+	authSvc := authService.NewService(store.refreshTokens, usersService, authConfig)
+	usersService.SetSessions(authSvc)
+	authHandler := handlers.NewAuthHandler(authSvc)
+	authMiddleware := handlers.NewAuthMiddleware(authSvc)
+
 	// Инициализируем echo
 	e := echo.New()
+	srv := server.New(e, cfg.HTTP)
 
-	// используем Logger и Recover
-	e.Use(middleware.Logger())
+	// Ошибки сервисов превращаем в ответы с правильным статусом
+	e.HTTPErrorHandler = handlers.NewErrorHandler()
+
+	// Трасса начинается здесь же, с учётом входящего traceparent. Пробы и /metrics не трассируем
+	e.Use(otelecho.Middleware(cfg.Tracing.ServiceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		switch c.Path() {
+		case "/healthz", "/readyz", "/metrics":
+			return true
+		}
+		return false
+	})))
+
+	// ID запроса ставим раньше всех, чтобы он попал в каждую строку лога
+	e.Use(logging.RequestIDMiddleware())
+
+	// Метрики стоят снаружи Recover, чтобы паника тоже посчиталась как 500
+	e.Use(logging.RequestLogger(logger))
+	e.Use(appMetrics.Middleware())
 	e.Use(middleware.Recover())
+	// Дедлайн на запрос: контекст уходит в gorm, и по истечении запрос к БД отменяется, а клиент получает 503
+	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Timeout:      cfg.HTTP.RequestTimeout,
+		ErrorHandler: handlers.TimeoutError,
+	}))
+	e.GET("/metrics", echo.WrapHandler(appMetrics.Handler()))
+
+	// operationMiddleware идёт последним, то есть снаружи: operationID запомнится и для 401
+	operationMiddleware := appMetrics.OperationMiddleware()
+
+	// Регистрация обработчиков аутентификации, они доступны без токена
+	authStrictHandler := auth.NewStrictHandler(authHandler, []auth.StrictMiddlewareFunc{operationMiddleware})
+	auth.RegisterHandlers(e, authStrictHandler)
 
 	// Регистрация обработчиков задач
-	tasksStrictHandler := tasks.NewStrictHandler(tasksHandler, nil)
+	tasksStrictHandler := tasks.NewStrictHandler(tasksHandler, []tasks.StrictMiddlewareFunc{authMiddleware, operationMiddleware})
 	tasks.RegisterHandlers(e, tasksStrictHandler)
 
+	// Регистрация обработчиков меток задач
+	labelsStrictHandler := labels.NewStrictHandler(labelsHandler, []labels.StrictMiddlewareFunc{authMiddleware, operationMiddleware})
+	labels.RegisterHandlers(e, labelsStrictHandler)
+
 	// Регистрация обработчиков пользователей
-	usersStrictHandler := users.NewStrictHandler(usersHandler, nil)
+	usersStrictHandler := users.NewStrictHandler(usersHandler, []users.StrictMiddlewareFunc{authMiddleware, operationMiddleware})
 	users.RegisterHandlers(e, usersStrictHandler)
 
-	if err := e.Start(":8080"); err != nil {
-		log.Fatalf("failed to start with err: %v", err)
+	// Регистрация обработчиков администрирования
+	adminHandler := handlers.NewAdminHandler(&logLevel)
+	adminStrictHandler := admin.NewStrictHandler(adminHandler, []admin.StrictMiddlewareFunc{authMiddleware, operationMiddleware})
+	admin.RegisterHandlers(e, adminStrictHandler)
+
+	// Пробы для оркестратора: живость процесса и готовность принимать трафик
+	healthHandler := handlers.NewHealthHandler(srv.Draining, cfg.HTTP.ReadyTimeout, store.checks...)
+	healthHandler.Register(e)
+
+	// По SIGINT/SIGTERM перестаём принимать соединения и дожидаемся начатых запросов
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Напоминания о задачах рассылаются в фоне, пока работает сервер
+	schedulerDone := make(chan struct{})
+	if cfg.Reminder.Enabled {
+		scheduler := reminder.NewScheduler(store.tasks, store.tx, reminder.LogNotifier{}, appMetrics,
+			cfg.Reminder.Interval, cfg.Reminder.BatchSize)
+		go func() {
+			defer close(schedulerDone)
+			scheduler.Run(ctx)
+		}()
+	} else {
+		close(schedulerDone)
+	}
+
+	runErr := srv.Run(ctx)
+	// Сервер мог упасть и сам, без сигнала. Планировщик должен закончить раньше, чем закроется база
+	stop()
+	<-schedulerDone
+	if err := store.close(); err != nil {
+		slog.Error("failed to close database", "error", err)
+	}
+
+	// Досылаем накопленные спаны, но не ждём коллектор бесконечно
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		slog.Error("failed to flush traces", "error", err)
+	}
+	if runErr != nil {
+		fatal("server stopped with error", runErr)
 	}
+	slog.Info("server stopped")
+}
+
+// fatal пишет ошибку в лог и завершает процесс
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
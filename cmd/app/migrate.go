@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"pet1/internal/config"
+	"pet1/internal/db"
+	"strconv"
+)
+
+const migrateUsage = `usage: app [flags] migrate <command>
+
+commands:
+  up             apply all new migrations
+  down [N]       roll back N migrations (default 1)
+  status         print current and latest schema version
+  force VERSION  mark VERSION as applied without running it, clears the dirty flag`
+
+// runMigrate выполняет подкоманду migrate. Настройки БД те же, что и у сервера,
+// поэтому миграции всегда идут в ту же базу, с которой работает приложение
+func runMigrate(cfg config.DBConfig, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	switch args[0] {
+	case "up", "down", "force", "status":
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+
+	migrator, err := db.NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("down: N must be a positive number, got %q", args[1])
+			}
+		}
+		if err := migrator.Down(steps); err != nil {
+			return err
+		}
+	case "force":
+		if len(args) < 2 {
+			return errors.New("force: VERSION is required")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("force: VERSION must be a number, got %q", args[1])
+		}
+		if err := migrator.Force(version); err != nil {
+			return err
+		}
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "version: %d\ndirty: %t\nlatest: %d\n", status.Version, status.Dirty, status.Latest)
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"pet1/internal/authService"
+	"pet1/internal/config"
+	"pet1/internal/db"
+	"pet1/internal/taskService"
+	"pet1/internal/userService"
+)
+
+// models - все модели gorm, которые должны совпадать со схемой из migrations
+var models = []interface{}{
+	&taskService.Task{},
+	&taskService.Label{},
+	&taskService.TaskLabel{},
+	&taskService.TaskDependency{},
+	&userService.User{},
+	&authService.RefreshToken{},
+}
+
+// runSchemaCheck выполняет подкоманду schema-check: печатает расхождения
+// моделей со схемой и завершается с ошибкой, если они есть
+func runSchemaCheck(cfg config.DBConfig) error {
+	if err := db.InitDB(cfg); err != nil {
+		return err
+	}
+	defer db.Close()
+
+	diffs, err := db.CheckSchema(context.Background(), models...)
+	if err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		fmt.Fprintln(os.Stdout, diff)
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("schema differs from models in %d places", len(diffs))
+	}
+	fmt.Fprintln(os.Stdout, "schema matches models")
+	return nil
+}
+
+// checkSchemaOnStartup сверяет схему при старте. В режиме warn расхождения
+// только пишутся в лог, в режиме fail сервер не стартует
+func checkSchemaOnStartup(mode string) error {
+	if mode == config.SchemaCheckOff {
+		return nil
+	}
+
+	diffs, err := db.CheckSchema(context.Background(), models...)
+	if err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		slog.Warn("schema drift", "table", diff.Table, "column", diff.Column, "problem", diff.Problem)
+	}
+	if len(diffs) > 0 && mode == config.SchemaCheckFail {
+		return fmt.Errorf("schema differs from models in %d places, run app schema-check for details", len(diffs))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"pet1/internal/authService"
+	"pet1/internal/config"
+	"pet1/internal/db"
+	"pet1/internal/handlers"
+	"pet1/internal/metrics"
+	"pet1/internal/taskService"
+	"pet1/internal/transaction"
+	"pet1/internal/userService"
+
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+)
+
+// storage - репозитории выбранного хранилища и всё, что к нему прилагается
+type storage struct {
+	tasks         taskService.TaskRepository
+	labels        taskService.LabelRepository
+	users         userService.UserRepository
+	refreshTokens authService.RefreshTokenRepository
+	// tx объединяет вызовы этих репозиториев в одну транзакцию
+	tx transaction.Manager
+	// checks - проверки для /readyz
+	checks []handlers.HealthCheck
+	close  func() error
+}
+
+// openStorage подключает базу из настроек или, для драйвера memory, создаёт репозитории в памяти
+func openStorage(cfg config.DBConfig, appMetrics *metrics.Metrics) (storage, error) {
+	if cfg.Driver == config.DriverMemory {
+		// Данные живут, пока живёт процесс. Пользователи и задачи связаны так же,
+		// как внешним ключом в базе
+		tasksRepo := taskService.NewMemoryTaskRepository()
+		usersRepo := userService.NewMemoryUserRepository(tasksRepo)
+		tasksRepo.SetUserLookup(usersRepo.Exists)
+		labelsRepo := taskService.NewMemoryLabelRepository()
+		tasksRepo.SetLabelLookup(labelsRepo.Lookup)
+		refreshTokensRepo := authService.NewMemoryRefreshTokenRepository()
+		slog.Warn("using in-memory storage, data is lost on restart")
+		return storage{
+			tasks:         tasksRepo,
+			labels:        labelsRepo,
+			users:         usersRepo,
+			refreshTokens: refreshTokensRepo,
+			tx:            transaction.NewMemoryManager(tasksRepo, labelsRepo, usersRepo, refreshTokensRepo),
+			close:         func() error { return nil },
+		}, nil
+	}
+
+	// Миграции при старте. Реплики, стартующие одновременно, дождутся друг друга на advisory lock
+	if cfg.AutoMigrate {
+		if err := db.Migrate(cfg); err != nil {
+			return storage{}, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+	// Версия схемы, под которую собран бинарник: с ней сверяется /readyz
+	schemaVersion, err := db.LatestMigration(cfg.Driver)
+	if err != nil {
+		return storage{}, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	// Инициализация БД
+	if err := db.InitDB(cfg); err != nil {
+		return storage{}, fmt.Errorf("failed to init database: %w", err)
+	}
+	// Модели gorm должны совпадать со схемой из миграций
+	if err := checkSchemaOnStartup(cfg.SchemaCheck); err != nil {
+		db.Close()
+		return storage{}, fmt.Errorf("schema check failed: %w", err)
+	}
+
+	// Метрики: время запросов gorm и статистика пула соединений
+	if err := db.DB.Use(appMetrics.GormPlugin()); err != nil {
+		db.Close()
+		return storage{}, fmt.Errorf("failed to register gorm metrics: %w", err)
+	}
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		db.Close()
+		return storage{}, fmt.Errorf("failed to get database handle: %w", err)
+	}
+	appMetrics.RegisterDB(sqlDB, cfg.Name)
+
+	// Каждый запрос gorm становится спаном внутри спана запроса.
+	// Свои метрики плагина выключены, их уже собирает Prometheus
+	if err := db.DB.Use(gormtracing.NewPlugin(gormtracing.WithDBName(cfg.Name), gormtracing.WithoutMetrics())); err != nil {
+		db.Close()
+		return storage{}, fmt.Errorf("failed to register gorm tracing: %w", err)
+	}
+
+	return storage{
+		tasks:         taskService.NewTaskRepository(db.DB),
+		labels:        taskService.NewLabelRepository(db.DB),
+		users:         userService.NewUserRepository(db.DB),
+		refreshTokens: authService.NewRefreshTokenRepository(db.DB),
+		tx:            transaction.NewGormManager(db.DB, cfg.TxMaxRetries),
+		checks: []handlers.HealthCheck{
+			{Name: "database", Check: db.Ping},
+			{Name: "migrations", Check: func(ctx context.Context) error {
+				return db.CheckMigrations(ctx, schemaVersion)
+			}},
+		},
+		close: db.Close,
+	}, nil
+}
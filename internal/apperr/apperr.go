@@ -0,0 +1,75 @@
+package apperr
+
+import (
+	"errors"
+	"strings"
+)
+
+// Виды ошибок. Сервисы объявляют свои ошибки поверх этих видов,
+// а HTTP-слой по виду решает, какой статус отдать клиенту
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrValidation      = errors.New("validation failed")
+	ErrForbidden       = errors.New("forbidden")
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+// Error - ошибка предметной области определённого вида
+type Error struct {
+	kind error
+	msg  string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Is позволяет писать errors.Is(err, apperr.ErrNotFound) для любой ошибки этого вида
+func (e *Error) Is(target error) bool {
+	return target == e.kind
+}
+
+func NotFound(msg string) *Error {
+	return &Error{kind: ErrNotFound, msg: msg}
+}
+
+func Conflict(msg string) *Error {
+	return &Error{kind: ErrConflict, msg: msg}
+}
+
+func Forbidden(msg string) *Error {
+	return &Error{kind: ErrForbidden, msg: msg}
+}
+
+func Unauthenticated(msg string) *Error {
+	return &Error{kind: ErrUnauthenticated, msg: msg}
+}
+
+// FieldError - ошибка в конкретном поле входных данных
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+// ValidationError - входные данные не прошли проверку. Может содержать несколько полей
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Invalid создаёт ошибку валидации для одного поля
+func Invalid(field, reason string) *ValidationError {
+	return &ValidationError{Fields: []FieldError{{Field: field, Reason: reason}}}
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Reason)
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
@@ -0,0 +1,123 @@
+package authService
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryRefreshTokenRepository - RefreshTokenRepository в памяти процесса,
+// чтобы сервер целиком работал без базы
+type memoryRefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[uint]RefreshToken
+	lastID uint
+}
+
+func NewMemoryRefreshTokenRepository() *memoryRefreshTokenRepository {
+	return &memoryRefreshTokenRepository{tokens: map[uint]RefreshToken{}}
+}
+
+func (r *memoryRefreshTokenRepository) CreateRefreshToken(ctx context.Context, token RefreshToken) (RefreshToken, error) {
+	if err := ctx.Err(); err != nil {
+		return RefreshToken{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// token_hash в базе уникален
+	for _, existing := range r.tokens {
+		if existing.TokenHash == token.TokenHash {
+			return RefreshToken{}, gorm.ErrDuplicatedKey
+		}
+	}
+
+	r.lastID++
+	token.ID = r.lastID
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	r.tokens[token.ID] = token
+	return token, nil
+}
+
+func (r *memoryRefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (RefreshToken, error) {
+	if err := ctx.Err(); err != nil {
+		return RefreshToken{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			return token, nil
+		}
+	}
+	return RefreshToken{}, ErrInvalidToken
+}
+
+func (r *memoryRefreshTokenRepository) RevokeRefreshToken(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, ok := r.tokens[id]
+	if !ok || token.RevokedAt != nil {
+		return ErrTokenRevoked
+	}
+	now := time.Now()
+	token.RevokedAt = &now
+	r.tokens[id] = token
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeUserTokens(ctx context.Context, userID uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			r.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+// Snapshot запоминает токены для отката транзакции
+func (r *memoryRefreshTokenRepository) Snapshot() func() {
+	r.mu.Lock()
+	saved := maps.Clone(r.tokens)
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		r.tokens = saved
+		r.mu.Unlock()
+	}
+}
@@ -0,0 +1,15 @@
+package authService
+
+import "time"
+
+// RefreshToken - выданный refresh-токен. Сам токен не храним, только его SHA-256
+type RefreshToken struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	UserID    uint   `json:"user_id"`
+	TokenHash string `json:"-"`
+	// FamilyID общий у всех токенов, полученных ротацией из одного логина
+	FamilyID  string     `json:"family_id" gorm:"index"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,77 @@
+package authService
+
+import (
+	"context"
+	"errors"
+	"pet1/internal/transaction"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	// CreateRefreshToken сохраняет новый refresh-токен
+	CreateRefreshToken(ctx context.Context, token RefreshToken) (RefreshToken, error)
+	// GetRefreshTokenByHash ищет токен по его хешу
+	GetRefreshTokenByHash(ctx context.Context, hash string) (RefreshToken, error)
+	// RevokeRefreshToken отзывает токен. Если токен уже был отозван,
+	// возвращает ErrTokenRevoked - так мы замечаем гонку двух ротаций
+	RevokeRefreshToken(ctx context.Context, id uint) error
+	// RevokeFamily отзывает все токены семейства
+	RevokeFamily(ctx context.Context, familyID string) error
+	// RevokeUserTokens отзывает все токены пользователя, например когда его удаляют
+	RevokeUserTokens(ctx context.Context, userID uint) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *refreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) CreateRefreshToken(ctx context.Context, token RefreshToken) (RefreshToken, error) {
+	result := transaction.Conn(ctx, r.db).Create(&token)
+	if result.Error != nil {
+		return RefreshToken{}, result.Error
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (RefreshToken, error) {
+	var token RefreshToken
+	result := transaction.Conn(ctx, r.db).Where("token_hash = ?", hash).First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return RefreshToken{}, ErrInvalidToken
+		}
+		return RefreshToken{}, result.Error
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) RevokeRefreshToken(ctx context.Context, id uint) error {
+	result := transaction.Conn(ctx, r.db).Model(&RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return transaction.Conn(ctx, r.db).Model(&RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *refreshTokenRepository) RevokeUserTokens(ctx context.Context, userID uint) error {
+	return transaction.Conn(ctx, r.db).Model(&RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,223 @@
+package authService
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"pet1/internal/apperr"
+	"pet1/internal/identity"
+	"pet1/internal/userService"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrInvalidToken - токен не прошёл проверку: подпись, срок жизни или формат
+	ErrInvalidToken = apperr.Unauthenticated("invalid token")
+	// ErrTokenRevoked - refresh-токен уже был отозван
+	ErrTokenRevoked = apperr.Unauthenticated("token revoked")
+)
+
+// Config - настройки выдачи токенов
+type Config struct {
+	// Secret - ключ подписи access-токенов (HS256)
+	Secret     []byte
+	Issuer     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// DefaultConfig возвращает настройки по умолчанию, кроме секрета
+func DefaultConfig() Config {
+	return Config{
+		Issuer:     "pet1",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 30 * 24 * time.Hour,
+	}
+}
+
+// accessClaims - содержимое access-токена
+type accessClaims struct {
+	Role identity.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// TokenPair - то, что получает клиент после входа или ротации
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+type AuthService struct {
+	repo  RefreshTokenRepository
+	users *userService.UserService
+	cfg   Config
+	now   func() time.Time
+}
+
+func NewService(repo RefreshTokenRepository, users *userService.UserService, cfg Config) *AuthService {
+	return &AuthService{repo: repo, users: users, cfg: cfg, now: time.Now}
+}
+
+// Login проверяет email и пароль и выдаёт новую пару токенов
+func (s *AuthService) Login(ctx context.Context, email, password string) (TokenPair, error) {
+	user, err := s.users.Authenticate(ctx, email, password)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	familyID, err := randomString(16)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return s.issue(ctx, user, familyID)
+}
+
+// Refresh меняет refresh-токен на новую пару. Старый токен сразу отзывается.
+// Повторное предъявление уже отозванного токена считаем утечкой
+// и отзываем всё семейство, чтобы украденная копия перестала работать
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (TokenPair, error) {
+	token, err := s.repo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if token.RevokedAt != nil {
+		if err := s.repo.RevokeFamily(ctx, token.FamilyID); err != nil {
+			return TokenPair{}, err
+		}
+		return TokenPair{}, ErrTokenRevoked
+	}
+	if !s.now().Before(token.ExpiresAt) {
+		return TokenPair{}, ErrInvalidToken
+	}
+
+	if err := s.repo.RevokeRefreshToken(ctx, token.ID); err != nil {
+		if errors.Is(err, ErrTokenRevoked) {
+			// Кто-то успел ротировать этот токен параллельно с нами
+			if err := s.repo.RevokeFamily(ctx, token.FamilyID); err != nil {
+				return TokenPair{}, err
+			}
+		}
+		return TokenPair{}, err
+	}
+
+	// Роль берём свежую: её могли поменять с момента прошлого входа.
+	// Пользователи удаляются мягко, и каскад в базе токены не отзывает:
+	// токен удалённого пользователя просто недействителен
+	user, err := s.users.GetUserByID(ctx, token.UserID)
+	if errors.Is(err, userService.ErrUserNotFound) {
+		if err := s.repo.RevokeFamily(ctx, token.FamilyID); err != nil {
+			return TokenPair{}, err
+		}
+		return TokenPair{}, ErrInvalidToken
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return s.issue(ctx, user, token.FamilyID)
+}
+
+// Logout отзывает всё семейство refresh-токена. Неизвестный токен не ошибка:
+// результат для клиента тот же - токен больше не действует
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	token, err := s.repo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil
+		}
+		return err
+	}
+	return s.repo.RevokeFamily(ctx, token.FamilyID)
+}
+
+// RevokeUserSessions отзывает все refresh-токены пользователя. Access-токены
+// доживают свой короткий срок
+func (s *AuthService) RevokeUserSessions(ctx context.Context, userID uint) error {
+	return s.repo.RevokeUserTokens(ctx, userID)
+}
+
+// ParseAccessToken проверяет access-токен и возвращает вызывающего
+func (s *AuthService) ParseAccessToken(accessToken string) (identity.Caller, error) {
+	claims := accessClaims{}
+	_, err := jwt.ParseWithClaims(accessToken, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.cfg.Secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(s.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil {
+		return identity.Caller{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 0)
+	if err != nil {
+		return identity.Caller{}, ErrInvalidToken
+	}
+	return identity.Caller{UserID: uint(userID), Role: claims.Role}, nil
+}
+
+func (s *AuthService) issue(ctx context.Context, user userService.User, familyID string) (TokenPair, error) {
+	now := s.now()
+
+	tokenID, err := randomString(16)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		Role: user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.cfg.Issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.AccessTTL)),
+			ID:        tokenID,
+		},
+	}).SignedString(s.cfg.Secret)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refreshToken, err := randomString(32)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	_, err = s.repo.CreateRefreshToken(ctx, RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(s.cfg.RefreshTTL),
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.cfg.AccessTTL,
+	}, nil
+}
+
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,91 @@
+package authService_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pet1/internal/authService"
+	"pet1/internal/password"
+	"pet1/internal/taskService"
+	"pet1/internal/transaction"
+	"pet1/internal/userService"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	email = "leaving@example.com"
+	plain = "correct horse"
+)
+
+type setup struct {
+	auth  *authService.AuthService
+	users *userService.UserService
+	repo  userService.UserRepository
+}
+
+func newSetup(t *testing.T) setup {
+	t.Helper()
+	tasks := taskService.NewMemoryTaskRepository()
+	users := userService.NewMemoryUserRepository(tasks)
+	tasks.SetUserLookup(users.Exists)
+	tokens := authService.NewMemoryRefreshTokenRepository()
+
+	cfg := password.DefaultConfig()
+	cfg.BcryptCost = bcrypt.MinCost
+	hasher, err := password.NewHasher(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usersService := userService.NewService(users, tasks, transaction.NewMemoryManager(tasks, users, tokens), hasher, false)
+	authConfig := authService.DefaultConfig()
+	authConfig.Secret = []byte("test-secret")
+	return setup{auth: authService.NewService(tokens, usersService, authConfig), users: usersService, repo: users}
+}
+
+func TestRefreshAfterUserDeleted(t *testing.T) {
+	cases := map[string]func(t *testing.T, s setup, id uint){
+		// Удаление через сервис сразу отзывает токены пользователя
+		"with sessions": func(t *testing.T, s setup, id uint) {
+			s.users.SetSessions(s.auth)
+			if err := s.users.DeleteUserByID(context.Background(), id); err != nil {
+				t.Fatal(err)
+			}
+		},
+		// Токены пережили удаление, например удалённого до этой правки: их отклоняет Refresh
+		"without sessions": func(t *testing.T, s setup, id uint) {
+			if err := s.repo.DeleteUserByID(context.Background(), id); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+
+	for name, deleteUser := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := newSetup(t)
+			ctx := context.Background()
+			user, err := s.users.CreateUser(ctx, userService.User{Email: email, Password: plain})
+			if err != nil {
+				t.Fatal(err)
+			}
+			pair, err := s.auth.Login(ctx, email, plain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			deleteUser(t, s, user.ID)
+
+			_, err = s.auth.Refresh(ctx, pair.RefreshToken)
+			if !errors.Is(err, authService.ErrInvalidToken) && !errors.Is(err, authService.ErrTokenRevoked) {
+				t.Fatalf("Refresh = %v, want 401 invalid or revoked token", err)
+			}
+			if errors.Is(err, userService.ErrUserNotFound) {
+				t.Fatal("Refresh leaks user not found")
+			}
+			// Второй раз токен уже отозван
+			if _, err := s.auth.Refresh(ctx, pair.RefreshToken); !errors.Is(err, authService.ErrTokenRevoked) {
+				t.Errorf("second Refresh = %v, want ErrTokenRevoked", err)
+			}
+		})
+	}
+}
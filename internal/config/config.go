@@ -0,0 +1,325 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"pet1/internal/password"
+)
+
+// Config - все настройки приложения.
+// Теги: yaml - ключ в файле конфигурации, env - переменная окружения,
+// secret - значение не печатается в логах. Флаги командной строки
+// строятся из yaml-пути автоматически: -db.host, -http.addr и т.д.
+type Config struct {
+	HTTP     HTTPConfig     `yaml:"http"`
+	DB       DBConfig       `yaml:"db"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Password PasswordConfig `yaml:"password"`
+	Admin    AdminConfig    `yaml:"admin"`
+	Log      LogConfig      `yaml:"log"`
+	Features FeaturesConfig `yaml:"features"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Reminder ReminderConfig `yaml:"reminder"`
+	Tasks    TasksConfig    `yaml:"tasks"`
+}
+
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr" env:"HTTP_ADDR"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// ShutdownTimeout - сколько ждать завершения начатых запросов при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+	// ShutdownDelay - сколько после сигнала /readyz уже отвечает 503, а сервер ещё
+	// принимает запросы. Даёт балансировщику время убрать под из ротации
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" env:"HTTP_SHUTDOWN_DELAY"`
+	// RequestTimeout - дедлайн контекста каждого запроса. По нему отменяются
+	// запросы к БД, если клиент ждёт слишком долго
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"HTTP_REQUEST_TIMEOUT"`
+	// ReadyTimeout - сколько ждать проверок зависимостей в /readyz
+	ReadyTimeout time.Duration `yaml:"ready_timeout" env:"HTTP_READY_TIMEOUT"`
+}
+
+type DBConfig struct {
+	// Driver - postgres, sqlite или memory. SQLite - для локальной разработки без Docker,
+	// memory - всё в памяти процесса, без базы и миграций: для моков и быстрых тестов
+	Driver string `yaml:"driver" env:"DB_DRIVER"`
+	// Path - файл базы SQLite, остальные параметры подключения для неё не нужны
+	Path string `yaml:"path" env:"DB_PATH"`
+
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+
+	// SlowQueryThreshold - запросы дольше этого попадают в лог как warn
+	SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" env:"DB_SLOW_QUERY_THRESHOLD"`
+
+	// AutoMigrate - накатывать встроенные миграции при старте
+	AutoMigrate bool `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE"`
+	// MigrateLockTimeout - сколько ждать, пока миграции накатывает другая реплика
+	MigrateLockTimeout time.Duration `yaml:"migrate_lock_timeout" env:"DB_MIGRATE_LOCK_TIMEOUT"`
+	// SchemaCheck - сверять ли модели gorm со схемой при старте: off, warn или fail
+	SchemaCheck string `yaml:"schema_check" env:"DB_SCHEMA_CHECK"`
+	// TxMaxRetries - сколько раз повторять транзакцию после конфликта сериализации или взаимной блокировки
+	TxMaxRetries int `yaml:"tx_max_retries" env:"DB_TX_MAX_RETRIES"`
+}
+
+// Поддерживаемые базы данных
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+// Режимы проверки схемы при старте
+const (
+	SchemaCheckOff  = "off"
+	SchemaCheckWarn = "warn"
+	SchemaCheckFail = "fail"
+)
+
+// DSN собирает строку подключения для выбранного драйвера
+func (c DBConfig) DSN() string {
+	if c.Driver == DriverSQLite {
+		// Внешние ключи в SQLite по умолчанию выключены, а busy_timeout
+		// заставляет ждать блокировку записи вместо мгновенной ошибки
+		sep := "?"
+		if strings.Contains(c.Path, "?") {
+			sep = "&"
+		}
+		return c.Path + sep + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	}
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		c.Host, c.User, c.Password, c.Name, c.Port, c.SSLMode)
+}
+
+type AuthConfig struct {
+	// JWTSecret - ключ подписи access-токенов. Пустой - сгенерируется случайный при старте
+	JWTSecret  string        `yaml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	AccessTTL  time.Duration `yaml:"access_ttl" env:"JWT_ACCESS_TTL"`
+	RefreshTTL time.Duration `yaml:"refresh_ttl" env:"JWT_REFRESH_TTL"`
+}
+
+type PasswordConfig struct {
+	Algorithm         password.Algorithm `yaml:"algorithm" env:"PASSWORD_ALGORITHM"`
+	BcryptCost        int                `yaml:"bcrypt_cost" env:"PASSWORD_BCRYPT_COST"`
+	Argon2Memory      uint32             `yaml:"argon2_memory" env:"PASSWORD_ARGON2_MEMORY"`
+	Argon2Iterations  uint32             `yaml:"argon2_iterations" env:"PASSWORD_ARGON2_ITERATIONS"`
+	Argon2Parallelism uint8              `yaml:"argon2_parallelism" env:"PASSWORD_ARGON2_PARALLELISM"`
+}
+
+// Hasher возвращает настройки для password.NewHasher
+func (c PasswordConfig) Hasher() password.Config {
+	cfg := password.DefaultConfig()
+	cfg.Algorithm = c.Algorithm
+	cfg.BcryptCost = c.BcryptCost
+	cfg.Argon2Memory = c.Argon2Memory
+	cfg.Argon2Iterations = c.Argon2Iterations
+	cfg.Argon2Parallelism = c.Argon2Parallelism
+	return cfg
+}
+
+// AdminConfig - первый админ, который создаётся на пустой базе
+type AdminConfig struct {
+	Email    string `yaml:"email" env:"ADMIN_EMAIL"`
+	Password string `yaml:"password" env:"ADMIN_PASSWORD" secret:"true"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+// TracingConfig - трассировка OpenTelemetry
+type TracingConfig struct {
+	// Exporter - куда отправлять спаны: none, stdout, file или otlp
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// File - файл для экспортёра file, удобно смотреть трассы без коллектора
+	File string `yaml:"file" env:"TRACING_FILE"`
+	// OTLPEndpoint - адрес коллектора, например http://localhost:4318
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	ServiceName  string  `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// FeaturesConfig - переключатели поведения
+type FeaturesConfig struct {
+	// PasswordRehash - пересчитывать хеш пароля при входе, если поменялись параметры хеширования
+	PasswordRehash bool `yaml:"password_rehash" env:"FEATURE_PASSWORD_REHASH"`
+}
+
+// ReminderConfig - фоновая рассылка напоминаний о задачах
+type ReminderConfig struct {
+	Enabled bool `yaml:"enabled" env:"REMINDER_ENABLED"`
+	// Interval - как часто проверять, не пора ли кому-то напомнить. Напоминание
+	// может опоздать на столько же
+	Interval time.Duration `yaml:"interval" env:"REMINDER_INTERVAL"`
+	// BatchSize - сколько напоминаний разбирать в одной транзакции
+	BatchSize int `yaml:"batch_size" env:"REMINDER_BATCH_SIZE"`
+}
+
+// TasksConfig - правила для подзадач
+type TasksConfig struct {
+	// MaxDepth - сколько уровней может быть в дереве задач, считая корневую задачу
+	MaxDepth int `yaml:"max_depth" env:"TASKS_MAX_DEPTH"`
+	// AutoCompleteParent - отмечать родителя сделанным, когда сделаны все его подзадачи
+	AutoCompleteParent bool `yaml:"auto_complete_parent" env:"TASKS_AUTO_COMPLETE_PARENT"`
+	// BlockOpenSubtasks - не давать отметить задачу сделанной, пока не сделаны все её подзадачи
+	BlockOpenSubtasks bool `yaml:"block_open_subtasks" env:"TASKS_BLOCK_OPEN_SUBTASKS"`
+}
+
+// Default возвращает настройки по умолчанию для базы из docker-compose.yml, к которой
+// подключаются с хоста: сервис db-serve-http с базой postgres на localhost:5432
+func Default() Config {
+	hasher := password.DefaultConfig()
+	return Config{
+		HTTP: HTTPConfig{
+			Addr:              ":8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+			RequestTimeout:    10 * time.Second,
+			ReadyTimeout:      2 * time.Second,
+		},
+		DB: DBConfig{
+			Driver:          DriverPostgres,
+			Path:            "pet1.db",
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Password:        "yourpassword",
+			Name:            "postgres",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+
+			SlowQueryThreshold: 200 * time.Millisecond,
+			MigrateLockTimeout: time.Minute,
+			SchemaCheck:        SchemaCheckWarn,
+			TxMaxRetries:       3,
+		},
+		Auth: AuthConfig{
+			AccessTTL:  15 * time.Minute,
+			RefreshTTL: 30 * 24 * time.Hour,
+		},
+		Password: PasswordConfig{
+			Algorithm:         hasher.Algorithm,
+			BcryptCost:        hasher.BcryptCost,
+			Argon2Memory:      hasher.Argon2Memory,
+			Argon2Iterations:  hasher.Argon2Iterations,
+			Argon2Parallelism: hasher.Argon2Parallelism,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Features: FeaturesConfig{
+			PasswordRehash: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			File:        "traces.jsonl",
+			ServiceName: "pet1",
+			SampleRatio: 1,
+		},
+		Reminder: ReminderConfig{
+			Enabled:   true,
+			Interval:  30 * time.Second,
+			BatchSize: 100,
+		},
+		Tasks: TasksConfig{
+			MaxDepth: 5,
+		},
+	}
+}
+
+// Validate проверяет, что с такими настройками вообще можно стартовать
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.HTTP.Addr != "", "http.addr must not be empty")
+	check(c.HTTP.ReadTimeout >= 0, "http.read_timeout must not be negative")
+	check(c.HTTP.ReadHeaderTimeout >= 0, "http.read_header_timeout must not be negative")
+	check(c.HTTP.WriteTimeout >= 0, "http.write_timeout must not be negative")
+	check(c.HTTP.IdleTimeout >= 0, "http.idle_timeout must not be negative")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout must be positive")
+	check(c.HTTP.ShutdownDelay >= 0, "http.shutdown_delay must not be negative")
+	check(c.HTTP.ReadyTimeout > 0, "http.ready_timeout must be positive")
+	check(c.HTTP.RequestTimeout > 0, "http.request_timeout must be positive")
+	check(c.HTTP.WriteTimeout == 0 || c.HTTP.RequestTimeout < c.HTTP.WriteTimeout,
+		"http.request_timeout must be shorter than http.write_timeout")
+
+	switch c.DB.Driver {
+	case DriverPostgres:
+		check(c.DB.Host != "", "db.host must not be empty")
+		check(c.DB.Port > 0 && c.DB.Port < 65536, "db.port must be between 1 and 65535")
+		check(c.DB.Name != "", "db.name must not be empty")
+	case DriverSQLite:
+		check(c.DB.Path != "", "db.path must not be empty")
+	case DriverMemory:
+	default:
+		check(false, "db.driver must be one of postgres, sqlite, memory")
+	}
+	check(c.DB.MaxOpenConns >= 0, "db.max_open_conns must not be negative")
+	check(c.DB.MaxIdleConns >= 0, "db.max_idle_conns must not be negative")
+	check(c.DB.MaxOpenConns == 0 || c.DB.MaxIdleConns <= c.DB.MaxOpenConns,
+		"db.max_idle_conns must not exceed db.max_open_conns")
+	check(c.DB.MigrateLockTimeout > 0, "db.migrate_lock_timeout must be positive")
+	check(c.DB.TxMaxRetries >= 0, "db.tx_max_retries must not be negative")
+	switch c.DB.SchemaCheck {
+	case SchemaCheckOff, SchemaCheckWarn, SchemaCheckFail:
+	default:
+		check(false, "db.schema_check must be one of off, warn, fail")
+	}
+
+	check(c.Auth.AccessTTL > 0, "auth.access_ttl must be positive")
+	check(c.Auth.RefreshTTL > c.Auth.AccessTTL, "auth.refresh_ttl must be longer than auth.access_ttl")
+	check(c.Auth.JWTSecret == "" || len(c.Auth.JWTSecret) >= 32, "auth.jwt_secret must be at least 32 bytes")
+
+	if _, err := password.NewHasher(c.Password.Hasher()); err != nil {
+		check(false, "password: %v", err)
+	}
+
+	check((c.Admin.Email == "") == (c.Admin.Password == ""), "admin.email and admin.password must be set together")
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "log.level must be one of debug, info, warn, error")
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "file", "otlp":
+	default:
+		check(false, "tracing.exporter must be one of none, stdout, file, otlp")
+	}
+	check(c.Tracing.Exporter != "file" || c.Tracing.File != "", "tracing.file must be set for the file exporter")
+	check(c.Tracing.ServiceName != "", "tracing.service_name must not be empty")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(c.Reminder.Interval > 0, "reminder.interval must be positive")
+	check(c.Reminder.BatchSize > 0, "reminder.batch_size must be positive")
+
+	check(c.Tasks.MaxDepth > 0 && c.Tasks.MaxDepth <= 20, "tasks.max_depth must be between 1 and 20")
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load собирает настройки по слоям, каждый следующий перекрывает предыдущий:
+// значения по умолчанию, затем YAML-файл (-config или CONFIG_FILE), затем
+// переменные окружения, затем флаги командной строки. Результат проверяется.
+// Вторым значением возвращаются аргументы после флагов, например подкоманда migrate
+func Load(args []string) (Config, []string, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to YAML config file")
+
+	// Флаги запоминаем как строки и применяем последними, после файла и окружения
+	flagValues := map[string]string{}
+	visit(&cfg, func(f field) {
+		fs.Func(f.path, "overrides "+f.path, func(value string) error {
+			flagValues[f.path] = value
+			return nil
+		})
+	})
+	if err := fs.Parse(args); err != nil {
+		return Config{}, nil, err
+	}
+
+	if *configFile != "" {
+		data, err := os.ReadFile(*configFile)
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, nil, fmt.Errorf("failed to parse config file %s: %w", *configFile, err)
+		}
+	}
+
+	var errs []error
+	visit(&cfg, func(f field) {
+		if f.env == "" {
+			return
+		}
+		if value, ok := os.LookupEnv(f.env); ok {
+			if err := setValue(f.value, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.env, err))
+			}
+		}
+	})
+	visit(&cfg, func(f field) {
+		if value, ok := flagValues[f.path]; ok {
+			if err := setValue(f.value, value); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %w", f.path, err))
+			}
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, fs.Args(), nil
+}
+
+// Redacted возвращает итоговые настройки в виде YAML с замазанными секретами
+func (c Config) Redacted() string {
+	redacted := c
+	visit(&redacted, func(f field) {
+		if f.secret && !f.value.IsZero() {
+			f.value.SetString("******")
+		}
+	})
+	out, err := yaml.Marshal(redacted)
+	if err != nil {
+		return fmt.Sprintf("failed to render config: %v", err)
+	}
+	return string(out)
+}
+
+// field - одна настройка: путь в YAML, имя переменной окружения и само значение
+type field struct {
+	path   string
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+// visit обходит все настройки-листья структуры
+func visit(cfg *Config, fn func(field)) {
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			path := sf.Tag.Get("yaml")
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			fv := v.Field(i)
+			if sf.Type.Kind() == reflect.Struct {
+				walk(fv, path)
+				continue
+			}
+			fn(field{
+				path:   path,
+				env:    sf.Tag.Get("env"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  fv,
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue разбирает строку в значение нужного типа
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// envVars - переменные, которые трогают тесты. Снаружи их быть не должно
+var envVars = []string{"CONFIG_FILE", "DB_HOST", "DB_PORT", "HTTP_REQUEST_TIMEOUT", "TASKS_AUTO_COMPLETE_PARENT", "REMINDER_BATCH_SIZE"}
+
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range envVars {
+		// Setenv вернёт прежнее значение после теста, Unsetenv убирает переменную на время теста
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func writeYAML(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	const yamlHost = "db:\n  host: from-yaml\n  port: 6000\nhttp:\n  request_timeout: 7s\n"
+
+	cases := []struct {
+		name string
+		yaml string
+		env  map[string]string
+		args []string
+
+		host           string
+		port           int
+		requestTimeout time.Duration
+	}{
+		{
+			name: "defaults",
+			host: "localhost", port: 5432, requestTimeout: 10 * time.Second,
+		},
+		{
+			name: "yaml over defaults",
+			yaml: yamlHost,
+			host: "from-yaml", port: 6000, requestTimeout: 7 * time.Second,
+		},
+		{
+			name: "env over yaml",
+			yaml: yamlHost,
+			env:  map[string]string{"DB_HOST": "from-env", "HTTP_REQUEST_TIMEOUT": "3s"},
+			host: "from-env", port: 6000, requestTimeout: 3 * time.Second,
+		},
+		{
+			name: "flags over env",
+			yaml: yamlHost,
+			env:  map[string]string{"DB_HOST": "from-env", "DB_PORT": "7000"},
+			args: []string{"-db.host", "from-flag", "-http.request_timeout=2s"},
+			host: "from-flag", port: 7000, requestTimeout: 2 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			args := tc.args
+			if tc.yaml != "" {
+				args = append([]string{"-config", writeYAML(t, tc.yaml)}, args...)
+			}
+
+			cfg, _, err := Load(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.DB.Host != tc.host || cfg.DB.Port != tc.port || cfg.HTTP.RequestTimeout != tc.requestTimeout {
+				t.Errorf("got host %q, port %d, request_timeout %v, want %q, %d, %v",
+					cfg.DB.Host, cfg.DB.Port, cfg.HTTP.RequestTimeout, tc.host, tc.port, tc.requestTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadFileFromEnvAndRest(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CONFIG_FILE", writeYAML(t, "tasks:\n  auto_complete_parent: false\n"))
+	t.Setenv("TASKS_AUTO_COMPLETE_PARENT", "true")
+
+	cfg, rest, err := Load([]string{"-reminder.batch_size", "10", "migrate", "up"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Tasks.AutoCompleteParent || cfg.Reminder.BatchSize != 10 {
+		t.Errorf("auto_complete_parent = %v, batch_size = %d, want true, 10", cfg.Tasks.AutoCompleteParent, cfg.Reminder.BatchSize)
+	}
+	// Всё после флагов - подкоманда
+	if !slices.Equal(rest, []string{"migrate", "up"}) {
+		t.Errorf("rest = %v, want [migrate up]", rest)
+	}
+}
+
+func TestLoadRejectsInvalidValues(t *testing.T) {
+	cases := []struct {
+		name string
+		yaml string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{name: "bad driver", args: []string{"-db.driver", "mysql"}, want: "db.driver"},
+		{name: "zero batch size", env: map[string]string{"REMINDER_BATCH_SIZE": "0"}, want: "reminder.batch_size"},
+		{name: "negative batch size", args: []string{"-reminder.batch_size=-5"}, want: "reminder.batch_size"},
+		{name: "zero request timeout", env: map[string]string{"HTTP_REQUEST_TIMEOUT": "0s"}, want: "http.request_timeout"},
+		{name: "negative shutdown timeout", yaml: "http:\n  shutdown_timeout: -1s\n", want: "http.shutdown_timeout"},
+		{name: "unparsable number", env: map[string]string{"DB_PORT": "five"}, want: "DB_PORT"},
+		{name: "unparsable duration", args: []string{"-http.request_timeout", "soon"}, want: "-http.request_timeout"},
+		{name: "unknown yaml key", yaml: "db:\n  hots: typo\n", want: "hots"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			args := tc.args
+			if tc.yaml != "" {
+				args = append([]string{"-config", writeYAML(t, tc.yaml)}, args...)
+			}
+			if _, _, err := Load(args); err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Load = %v, want an error about %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{"defaults are valid", func(*Config) {}, ""},
+		{"memory needs no connection", func(c *Config) { c.DB.Driver = DriverMemory; c.DB.Host = "" }, ""},
+		{"empty driver", func(c *Config) { c.DB.Driver = "" }, "db.driver"},
+		{"sqlite without path", func(c *Config) { c.DB.Driver = DriverSQLite; c.DB.Path = "" }, "db.path"},
+		{"zero batch size", func(c *Config) { c.Reminder.BatchSize = 0 }, "reminder.batch_size"},
+		{"zero reminder interval", func(c *Config) { c.Reminder.Interval = 0 }, "reminder.interval"},
+		{"zero ready timeout", func(c *Config) { c.HTTP.ReadyTimeout = 0 }, "http.ready_timeout"},
+		{"zero migrate lock timeout", func(c *Config) { c.DB.MigrateLockTimeout = 0 }, "db.migrate_lock_timeout"},
+		{"zero access ttl", func(c *Config) { c.Auth.AccessTTL = 0 }, "auth.access_ttl"},
+		{"request timeout not under write timeout", func(c *Config) { c.HTTP.RequestTimeout = c.HTTP.WriteTimeout }, "http.request_timeout"},
+		{"short jwt secret", func(c *Config) { c.Auth.JWTSecret = "short" }, "auth.jwt_secret"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Default()
+			tc.modify(&cfg)
+			err := cfg.Validate()
+			if tc.want == "" {
+				if err != nil {
+					t.Errorf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Validate = %v, want an error about %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.DB.Password = "db-password-value"
+	cfg.Admin.Email = "admin@example.com"
+	cfg.Admin.Password = "admin-password-value"
+
+	out := cfg.Redacted()
+	for _, secret := range []string{cfg.DB.Password, cfg.Admin.Password} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Redacted output contains secret %q", secret)
+		}
+	}
+	if strings.Count(out, "******") != 2 {
+		t.Errorf("want two masked secrets, the empty jwt_secret stays empty:\n%s", out)
+	}
+	if !strings.Contains(out, "admin@example.com") || !strings.Contains(out, "host: localhost") {
+		t.Errorf("Redacted hides more than secrets:\n%s", out)
+	}
+	// Маскируется копия, сами настройки не меняются
+	if cfg.DB.Password != "db-password-value" {
+		t.Errorf("Redacted changed the config: db.password = %q", cfg.DB.Password)
+	}
+}
@@ -1,22 +1,110 @@
 package db
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"pet1/internal/config"
+	"pet1/internal/logging"
+	"time"
+
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"log"
 )
 
 // переменная, через которую мы будем работать с БД
 var DB *gorm.DB
 
-func InitDB() {
-	// в dsn вводим данные, которые мы указали при создании контейнера
-	dsn := "host=localhost user=postgres password=yourpassword dbname=main port=5432 sslmode=disable"
-	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func InitDB(cfg config.DBConfig) error {
+	conn, err := Open(cfg)
+	if err != nil {
+		return err
+	}
+	DB = conn
+	slog.Info("database initialized")
+	return nil
+}
+
+// Open подключается к базе с общими для приложения настройками gorm и пула.
+// В отличие от InitDB, не трогает глобальный DB: так базы открывают тесты
+func Open(cfg config.DBConfig) (*gorm.DB, error) {
+	// TranslateError превращает ошибки драйвера (например, нарушение уникальности)
+	// в общие ошибки gorm, которые репозитории умеют распознавать.
+	// Логи gorm идут в общий slog, с ID запроса из контекста
+	dialector, err := newDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := gorm.Open(dialector, &gorm.Config{
+		TranslateError: true,
+		Logger:         logging.NewGormLogger(slog.Default(), cfg.SlowQueryThreshold),
+		// SQLite хранит время строкой и сравнивает его как строку, так что пишем всё в UTC,
+		// иначе created_at с разными смещениями сравнивались бы неправильно
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// Настраиваем пул соединений
+	sqlDB, err := conn.DB()
 	if err != nil {
-		log.Fatal("Failed to connect to database: ", err)
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
 	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return conn, nil
+}
+
+// newDialector выбирает драйвер gorm по настройкам
+func newDialector(cfg config.DBConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		return postgres.Open(cfg.DSN()), nil
+	case config.DriverSQLite:
+		return sqlite.Open(cfg.DSN()), nil
+	}
+	return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+}
 
-	log.Println("Database initialized successfully")
+// Close закрывает пул соединений с БД
+func Close() error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Ping проверяет, что база отвечает
+func Ping(ctx context.Context) error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// CheckMigrations сверяет версию схемы, записанную migrate в schema_migrations,
+// с той, под которую собрано приложение. Более новая схема допустима: так бывает,
+// пока при выкладке старые экземпляры ещё работают рядом с новыми
+func CheckMigrations(ctx context.Context, expected uint) error {
+	var state struct {
+		Version uint
+		Dirty   bool
+	}
+	err := DB.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&state).Error
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if state.Dirty {
+		return fmt.Errorf("migration %d is dirty", state.Version)
+	}
+	if state.Version < expected {
+		return fmt.Errorf("schema version %d is behind expected %d", state.Version, expected)
+	}
+	return nil
 }
@@ -0,0 +1,36 @@
+// Package dbtest поднимает для тестов настоящую базу без Docker: файл SQLite
+// во временном каталоге с накатанными миграциями
+package dbtest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"pet1/internal/config"
+	"pet1/internal/db"
+
+	"gorm.io/gorm"
+)
+
+// SQLite открывает новую базу SQLite со всеми встроенными миграциями. Файл живёт
+// во временном каталоге теста, соединения закрываются вместе с тестом
+func SQLite(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	cfg := config.Default().DB
+	cfg.Driver = config.DriverSQLite
+	cfg.Path = filepath.Join(t.TempDir(), "test.db")
+	if err := db.Migrate(cfg); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	conn, err := db.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return conn
+}
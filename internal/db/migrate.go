@@ -0,0 +1,204 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"pet1/internal/config"
+	"pet1/migrations"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// MigrationStatus - состояние схемы
+type MigrationStatus struct {
+	// Version - последняя применённая миграция, 0 - ни одной
+	Version uint
+	// Dirty - миграция упала посередине, нужна ручная починка и force
+	Dirty bool
+	// Latest - последняя миграция, встроенная в бинарник
+	Latest uint
+}
+
+// Migrator накатывает встроенные миграции через golang-migrate.
+// Пока идёт up или down, migrate держит advisory lock в Postgres,
+// поэтому несколько реплик, стартующих одновременно, не мешают друг другу:
+// вторая дождётся первой и увидит, что делать уже нечего.
+// У SQLite свой набор миграций, см. migrations/sqlite
+type Migrator struct {
+	m      *migrate.Migrate
+	driver string
+}
+
+// NewMigrator открывает отдельное соединение с БД: migrate закрывает
+// свой *sql.DB вместе с собой, общий пул приложения трогать нельзя
+func NewMigrator(cfg config.DBConfig) (*Migrator, error) {
+	src, err := migrationSource(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := migrationDriver(cfg)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, cfg.Driver, driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("failed to init migrations: %w", err)
+	}
+	m.LockTimeout = cfg.MigrateLockTimeout
+	m.Log = migrateLogger{}
+	return &Migrator{m: m, driver: cfg.Driver}, nil
+}
+
+// migrationDriver открывает соединение для migrate под выбранную базу
+func migrationDriver(cfg config.DBConfig) (database.Driver, error) {
+	var (
+		sqlDB  *sql.DB
+		driver database.Driver
+		err    error
+	)
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		if sqlDB, err = sql.Open("pgx", cfg.DSN()); err == nil {
+			driver, err = pgx.WithInstance(sqlDB, &pgx.Config{})
+		}
+	case config.DriverSQLite:
+		// Драйвер "sqlite" регистрирует github.com/glebarez/sqlite
+		if sqlDB, err = sql.Open("sqlite", cfg.DSN()); err == nil {
+			driver, err = newSQLiteDriver(sqlDB)
+		}
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+	}
+	if err != nil {
+		if sqlDB != nil {
+			sqlDB.Close()
+		}
+		return nil, fmt.Errorf("failed to init migrations driver: %w", err)
+	}
+	return driver, nil
+}
+
+// migrationSource отдаёт миграции для выбранной базы
+func migrationSource(driver string) (source.Driver, error) {
+	fsys, err := migrations.FS(driver)
+	if err != nil {
+		return nil, err
+	}
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	return src, nil
+}
+
+// Up накатывает все новые миграции
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down откатывает steps последних миграций
+func (m *Migrator) Down(steps int) error {
+	if err := m.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Force записывает версию без выполнения миграций. Нужен после ручной починки dirty-состояния
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Status возвращает текущую и последнюю доступную версию схемы
+func (m *Migrator) Status() (MigrationStatus, error) {
+	latest, err := LatestMigration(m.driver)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{Latest: latest}, nil
+	}
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	return MigrationStatus{Version: version, Dirty: dirty, Latest: latest}, nil
+}
+
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// LatestMigration возвращает версию последней встроенной миграции для базы driver.
+// Под неё собрано приложение, с ней сверяется /readyz
+func LatestMigration(driver string) (uint, error) {
+	src, err := migrationSource(driver)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read embedded migrations: %w", err)
+		}
+		version = next
+	}
+}
+
+// migrateLogger пересылает сообщения migrate в slog
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...interface{}) {
+	slog.Info(fmt.Sprintf(format, v...), "component", "migrate")
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
+
+// Migrate накатывает миграции при старте, если это включено в настройках
+func Migrate(cfg config.DBConfig) error {
+	migrator, err := NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	start := time.Now()
+	if err := migrator.Up(); err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	slog.Info("migrations applied", "version", status.Version, "elapsed", time.Since(start))
+	return nil
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/golang-migrate/migrate/v4/database"
+)
+
+// sqliteDriver - драйвер golang-migrate поверх уже открытого *sql.DB с SQLite.
+// Готовый драйвер migrate тянет modernc.org/sqlite, который регистрирует то же имя
+// "sqlite", что и драйвер gorm, и две регистрации роняют программу при старте.
+// Таблица версий та же, что у migrate: schema_migrations(version, dirty)
+type sqliteDriver struct {
+	db       *sql.DB
+	isLocked atomic.Bool
+}
+
+func newSQLiteDriver(db *sql.DB) (*sqliteDriver, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL, dirty BOOLEAN NOT NULL);
+CREATE UNIQUE INDEX IF NOT EXISTS version_unique ON schema_migrations (version);`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return &sqliteDriver{db: db}, nil
+}
+
+func (d *sqliteDriver) Open(string) (database.Driver, error) {
+	return nil, errors.New("sqlite migrations driver is created from an open connection only")
+}
+
+func (d *sqliteDriver) Close() error {
+	return d.db.Close()
+}
+
+// Lock и Unlock защищают только от параллельных миграций в одном процессе:
+// SQLite - локальный файл, реплик, которые могли бы гоняться, у него нет
+func (d *sqliteDriver) Lock() error {
+	if !d.isLocked.CompareAndSwap(false, true) {
+		return database.ErrLocked
+	}
+	return nil
+}
+
+func (d *sqliteDriver) Unlock() error {
+	if !d.isLocked.CompareAndSwap(true, false) {
+		return database.ErrNotLocked
+	}
+	return nil
+}
+
+// Run выполняет миграцию целиком в одной транзакции
+func (d *sqliteDriver) Run(migration io.Reader) error {
+	query, err := io.ReadAll(migration)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(string(query)); err != nil {
+		tx.Rollback()
+		return database.Error{OrigErr: err, Err: "migration failed", Query: query}
+	}
+	return tx.Commit()
+}
+
+func (d *sqliteDriver) SetVersion(version int, dirty bool) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	// Как и в драйверах migrate: пустая таблица означает «миграций нет»,
+	// но dirty-состояние без версии тоже нужно запомнить
+	if version >= 0 || (version == database.NilVersion && dirty) {
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)`, version, dirty); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (d *sqliteDriver) Version() (int, bool, error) {
+	var version int
+	var dirty bool
+	err := d.db.QueryRow(`SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
+
+// Drop удаляет все таблицы, включая schema_migrations
+func (d *sqliteDriver) Drop() error {
+	rows, err := d.db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if _, err := d.db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %q`, table)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
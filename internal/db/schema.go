@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SchemaDiff - одно расхождение между моделью gorm и живой базой
+type SchemaDiff struct {
+	Table   string
+	Column  string
+	Problem string
+}
+
+func (d SchemaDiff) String() string {
+	if d.Column == "" {
+		return fmt.Sprintf("%s: %s", d.Table, d.Problem)
+	}
+	return fmt.Sprintf("%s.%s: %s", d.Table, d.Column, d.Problem)
+}
+
+// compatibleTypes - какие типы колонок в Postgres подходят под тип поля модели.
+// Время - только с часовым поясом: gorm читает и пишет time.Time с поясом
+var compatibleTypes = map[schema.DataType][]string{
+	schema.Bool:   {"bool", "boolean"},
+	schema.Int:    {"int2", "int4", "int8", "smallint", "integer", "bigint"},
+	schema.Uint:   {"int2", "int4", "int8", "smallint", "integer", "bigint"},
+	schema.Float:  {"float4", "float8", "numeric", "real", "double precision"},
+	schema.String: {"text", "varchar", "bpchar", "character varying"},
+	schema.Time:   {"timestamptz"},
+	schema.Bytes:  {"bytea"},
+}
+
+// sqliteCompatibleTypes - то же для SQLite. Здесь это объявленные в CREATE TABLE имена:
+// строгих типов у SQLite нет, но по ним видно, что миграция писалась под модель
+var sqliteCompatibleTypes = map[schema.DataType][]string{
+	schema.Bool:   {"bool", "boolean"},
+	schema.Int:    {"integer", "int", "smallint", "bigint"},
+	schema.Uint:   {"integer", "int", "smallint", "bigint"},
+	schema.Float:  {"real", "float", "double", "numeric"},
+	schema.String: {"text", "varchar"},
+	schema.Time:   {"datetime", "timestamp"},
+	schema.Bytes:  {"blob"},
+}
+
+// columnType приводит тип колонки к виду из таблиц выше: VARCHAR(255) -> varchar
+func columnType(column gorm.ColumnType) string {
+	dbType := strings.ToLower(column.DatabaseTypeName())
+	if i := strings.IndexByte(dbType, '('); i >= 0 {
+		dbType = strings.TrimSpace(dbType[:i])
+	}
+	return dbType
+}
+
+// DBManaged - модель, у таблицы которой есть колонки, которые ведёт сама база,
+// например генерируемые. В модели их нет, и расхождением это не считается
+type DBManaged interface {
+	DBManagedColumns() []string
+}
+
+// CheckSchema сравнивает модели gorm с таблицами в базе: есть ли таблицы и колонки,
+// подходят ли типы, NOT NULL там, где его требует модель, и индексы из тегов index.
+// Колонки, которых нет в модели, тоже попадают в отчёт
+func CheckSchema(ctx context.Context, models ...interface{}) ([]SchemaDiff, error) {
+	tx := DB.WithContext(ctx)
+	migrator := tx.Migrator()
+	types := compatibleTypes
+	if tx.Dialector.Name() == "sqlite" {
+		types = sqliteCompatibleTypes
+	}
+
+	var diffs []SchemaDiff
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			diffs = append(diffs, SchemaDiff{Table: table, Problem: "table is missing"})
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		live := map[string]gorm.ColumnType{}
+		for _, column := range columnTypes {
+			live[column.Name()] = column
+		}
+
+		for _, name := range stmt.Schema.DBNames {
+			field := stmt.Schema.FieldsByDBName[name]
+			column, ok := live[name]
+			if !ok {
+				diffs = append(diffs, SchemaDiff{Table: table, Column: name, Problem: "column is missing"})
+				continue
+			}
+			delete(live, name)
+
+			dbType := columnType(column)
+			if expected, known := types[field.DataType]; known && !slices.Contains(expected, dbType) {
+				diffs = append(diffs, SchemaDiff{Table: table, Column: name,
+					Problem: fmt.Sprintf("type %s does not match model type %s", dbType, field.DataType)})
+			}
+			if field.NotNull || field.PrimaryKey {
+				if nullable, ok := column.Nullable(); ok && nullable {
+					diffs = append(diffs, SchemaDiff{Table: table, Column: name, Problem: "column is nullable, model requires NOT NULL"})
+				}
+			}
+		}
+
+		if managed, ok := model.(DBManaged); ok {
+			for _, name := range managed.DBManagedColumns() {
+				delete(live, name)
+			}
+		}
+
+		extra := make([]string, 0, len(live))
+		for name := range live {
+			extra = append(extra, name)
+		}
+		sort.Strings(extra)
+		for _, name := range extra {
+			diffs = append(diffs, SchemaDiff{Table: table, Column: name, Problem: "column is not in the model"})
+		}
+
+		indexes := stmt.Schema.ParseIndexes()
+		names := make([]string, 0, len(indexes))
+		for name := range indexes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !migrator.HasIndex(model, name) {
+				diffs = append(diffs, SchemaDiff{Table: table, Problem: fmt.Sprintf("index %s is missing", name)})
+			}
+		}
+	}
+	return diffs, nil
+}
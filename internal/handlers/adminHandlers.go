@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"pet1/internal/apperr"
+	"pet1/internal/logging"
+	"pet1/internal/policy"
+	"pet1/internal/web/admin"
+)
+
+// AdminHandler обрабатывает настройки работающего сервера
+type AdminHandler struct {
+	// LogLevel - уровень общего логгера, меняется без перезапуска
+	LogLevel *slog.LevelVar
+}
+
+func NewAdminHandler(logLevel *slog.LevelVar) *AdminHandler {
+	return &AdminHandler{
+		LogLevel: logLevel,
+	}
+}
+
+// GetAdminLogLevel возвращает текущий уровень логирования
+func (h *AdminHandler) GetAdminLogLevel(ctx context.Context, _ admin.GetAdminLogLevelRequestObject) (admin.GetAdminLogLevelResponseObject, error) {
+	if !allowed(ctx, policy.ManageSettings) {
+		return nil, errForbidden
+	}
+
+	return admin.GetAdminLogLevel200JSONResponse{
+		Level: admin.LogLevelLevel(logging.LevelName(h.LogLevel.Level())),
+	}, nil
+}
+
+// PutAdminLogLevel меняет уровень логирования на лету
+func (h *AdminHandler) PutAdminLogLevel(ctx context.Context, request admin.PutAdminLogLevelRequestObject) (admin.PutAdminLogLevelResponseObject, error) {
+	if !allowed(ctx, policy.ManageSettings) {
+		return nil, errForbidden
+	}
+
+	level, err := logging.ParseLevel(string(request.Body.Level))
+	if err != nil {
+		return nil, apperr.Invalid("level", "must be one of debug, info, warn, error")
+	}
+
+	previous := h.LogLevel.Level()
+	h.LogLevel.Set(level)
+	slog.WarnContext(ctx, "log level changed", "from", logging.LevelName(previous), "to", logging.LevelName(level))
+
+	return admin.PutAdminLogLevel200JSONResponse{
+		Level: admin.LogLevelLevel(logging.LevelName(level)),
+	}, nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"pet1/internal/authService"
+	"pet1/internal/web/auth"
+)
+
+// AuthHandler обрабатывает вход, ротацию токенов и выход
+type AuthHandler struct {
+	Service *authService.AuthService
+}
+
+func NewAuthHandler(service *authService.AuthService) *AuthHandler {
+	return &AuthHandler{
+		Service: service,
+	}
+}
+
+// PostAuthLogin реализует вход по email и паролю
+func (h *AuthHandler) PostAuthLogin(ctx context.Context, request auth.PostAuthLoginRequestObject) (auth.PostAuthLoginResponseObject, error) {
+	pair, err := h.Service.Login(ctx, request.Body.Email, request.Body.Password)
+	if err != nil {
+		// Неверные email или пароль превратятся в 401
+		return nil, fmt.Errorf("failed to login: %w", err)
+	}
+
+	return auth.PostAuthLogin200JSONResponse(toTokenPairResponse(pair)), nil
+}
+
+// PostAuthRefresh реализует ротацию refresh-токена
+func (h *AuthHandler) PostAuthRefresh(ctx context.Context, request auth.PostAuthRefreshRequestObject) (auth.PostAuthRefreshResponseObject, error) {
+	pair, err := h.Service.Refresh(ctx, request.Body.RefreshToken)
+	if err != nil {
+		// Недействительный, отозванный или истёкший токен превратится в 401
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	return auth.PostAuthRefresh200JSONResponse(toTokenPairResponse(pair)), nil
+}
+
+// PostAuthLogout реализует выход
+func (h *AuthHandler) PostAuthLogout(ctx context.Context, request auth.PostAuthLogoutRequestObject) (auth.PostAuthLogoutResponseObject, error) {
+	if err := h.Service.Logout(ctx, request.Body.RefreshToken); err != nil {
+		return nil, fmt.Errorf("failed to logout: %w", err)
+	}
+
+	return auth.PostAuthLogout204Response{}, nil
+}
+
+func toTokenPairResponse(pair authService.TokenPair) auth.TokenPair {
+	return auth.TokenPair{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(pair.ExpiresIn.Seconds()),
+	}
+}
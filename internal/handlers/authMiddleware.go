@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"pet1/internal/authService"
+	"pet1/internal/identity"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// NewAuthMiddleware возвращает strict-middleware, которое пускает дальше только
+// запросы с действительным access-токеном в заголовке Authorization.
+// Вызывающий кладётся в контекст запроса, откуда его достают хендлеры и сервисы
+func NewAuthMiddleware(service *authService.AuthService) strictecho.StrictEchoMiddlewareFunc {
+	return func(f strictecho.StrictEchoHandlerFunc, _ string) strictecho.StrictEchoHandlerFunc {
+		return func(ctx echo.Context, request interface{}) (interface{}, error) {
+			header := ctx.Request().Header.Get(echo.HeaderAuthorization)
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || token == "" {
+				return nil, unauthorized(ctx)
+			}
+
+			caller, err := service.ParseAccessToken(token)
+			if err != nil {
+				return nil, unauthorized(ctx)
+			}
+
+			req := ctx.Request()
+			ctx.SetRequest(req.WithContext(identity.NewContext(req.Context(), caller)))
+			return f(ctx, request)
+		}
+	}
+}
+
+func unauthorized(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	return echo.NewHTTPError(http.StatusUnauthorized, "missing or invalid access token")
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"pet1/internal/apperr"
+
+	"github.com/labstack/echo/v4"
+)
+
+// problemContentType - тип содержимого ответов с ошибками по RFC 7807
+const problemContentType = "application/problem+json"
+
+// problem - тело ответа с ошибкой по RFC 7807
+type problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []fieldError `json:"errors,omitempty"`
+}
+
+type fieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// httpStatus сопоставляет вид ошибки предметной области со статусом HTTP.
+// Всё, что не распознано, считается внутренней ошибкой
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperr.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperr.ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, apperr.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperr.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// NewErrorHandler возвращает обработчик ошибок echo, который отвечает на любую ошибку
+// в формате application/problem+json. Strict-хендлеры отдают ошибки сюда же:
+// у echo-варианта strict-сервера нет своих хуков для ошибок
+func NewErrorHandler() echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		p := toProblem(err)
+		p.Instance = c.Request().URL.Path
+
+		if p.Status >= http.StatusInternalServerError {
+			// Подробности внутренних ошибок клиенту не показываем, только в лог
+			slog.ErrorContext(c.Request().Context(), "request failed", "error", err)
+		}
+
+		var sendErr error
+		if c.Request().Method == http.MethodHead {
+			sendErr = c.NoContent(p.Status)
+		} else {
+			c.Response().Header().Set(echo.HeaderContentType, problemContentType)
+			sendErr = c.JSON(p.Status, p)
+		}
+		if sendErr != nil {
+			slog.ErrorContext(c.Request().Context(), "failed to send error response", "error", sendErr)
+		}
+	}
+}
+
+// TimeoutError - обработчик ошибок для middleware.ContextTimeout. Сам echo отдаёт 503,
+// только если ошибка оборачивает context.DeadlineExceeded, а драйвер SQLite, прервав
+// запрос по дедлайну, возвращает свою ошибку interrupted. Поэтому смотрим на контекст
+// запроса: истёк дедлайн - 503, какой бы ни была ошибка
+func TimeoutError(err error, c echo.Context) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(c.Request().Context().Err(), context.DeadlineExceeded) {
+		return echo.ErrServiceUnavailable.WithInternal(err)
+	}
+	return err
+}
+
+func toProblem(err error) problem {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		// Ошибки самого echo: не найден маршрут, не разобралось тело запроса, нет токена и т.п.
+		p := newProblem(httpErr.Code)
+		if httpErr.Code < http.StatusInternalServerError {
+			p.Detail = fmt.Sprint(httpErr.Message)
+		}
+		return p
+	}
+
+	p := newProblem(httpStatus(err))
+	if p.Status == http.StatusInternalServerError {
+		return p
+	}
+
+	var validationErr *apperr.ValidationError
+	var domainErr *apperr.Error
+	switch {
+	case errors.As(err, &validationErr):
+		p.Detail = "request contains invalid fields"
+		for _, f := range validationErr.Fields {
+			p.Errors = append(p.Errors, fieldError{Field: f.Field, Reason: f.Reason})
+		}
+	case errors.As(err, &domainErr):
+		// Берём сообщение самой ошибки сервиса, без обёрток хендлеров
+		p.Detail = domainErr.Error()
+	default:
+		p.Detail = err.Error()
+	}
+	return p
+}
+
+func newProblem(status int) problem {
+	return problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"pet1/internal/apperr"
+	"pet1/internal/taskService"
+	"pet1/internal/userService"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", apperr.NotFound("thing not found"), http.StatusNotFound},
+		{"conflict", apperr.Conflict("already exists"), http.StatusConflict},
+		{"validation", apperr.Invalid("name", "must not be empty"), http.StatusUnprocessableEntity},
+		{"forbidden", apperr.Forbidden("nope"), http.StatusForbidden},
+		{"unauthenticated", apperr.Unauthenticated("who are you"), http.StatusUnauthorized},
+		{"kind itself", apperr.ErrNotFound, http.StatusNotFound},
+		{"service sentinel", taskService.ErrTaskNotFound, http.StatusNotFound},
+		{"user sentinel", userService.ErrEmailTaken, http.StatusConflict},
+		// Хендлеры оборачивают ошибки сервиса через %w, вид должен сохраниться
+		{"wrapped", fmt.Errorf("failed to delete task: %w", taskService.ErrTaskNotFound), http.StatusNotFound},
+		{"wrapped twice", fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", apperr.Invalid("a", "b"))), http.StatusUnprocessableEntity},
+		{"joined", errors.Join(errors.New("other"), apperr.Conflict("c")), http.StatusConflict},
+		{"plain error", errors.New("task not found"), http.StatusInternalServerError},
+		{"context", context.DeadlineExceeded, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := httpStatus(tt.err); got != tt.want {
+				t.Errorf("httpStatus(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want problem
+	}{
+		{
+			name: "domain error detail without handler wrapping",
+			err:  fmt.Errorf("failed to update task: %w", taskService.ErrTaskNotFound),
+			want: problem{Type: "about:blank", Title: "Not Found", Status: 404, Detail: "task not found"},
+		},
+		{
+			name: "validation error lists fields",
+			err: fmt.Errorf("failed to create user: %w", &apperr.ValidationError{Fields: []apperr.FieldError{
+				{Field: "email", Reason: "must not be empty"},
+				{Field: "password", Reason: "too short"},
+			}}),
+			want: problem{
+				Type: "about:blank", Title: "Unprocessable Entity", Status: 422,
+				Detail: "request contains invalid fields",
+				Errors: []fieldError{{Field: "email", Reason: "must not be empty"}, {Field: "password", Reason: "too short"}},
+			},
+		},
+		{
+			name: "echo client error keeps message",
+			err:  echo.NewHTTPError(http.StatusBadRequest, "invalid id"),
+			want: problem{Type: "about:blank", Title: "Bad Request", Status: 400, Detail: "invalid id"},
+		},
+		{
+			name: "echo server error hides message",
+			err:  echo.NewHTTPError(http.StatusServiceUnavailable, "db is down"),
+			want: problem{Type: "about:blank", Title: "Service Unavailable", Status: 503},
+		},
+		{
+			name: "wrapped echo error",
+			err:  fmt.Errorf("bind: %w", echo.ErrUnsupportedMediaType),
+			want: problem{Type: "about:blank", Title: "Unsupported Media Type", Status: 415, Detail: "Unsupported Media Type"},
+		},
+		{
+			name: "internal error hides details",
+			err:  errors.New("pq: password authentication failed for user postgres"),
+			want: problem{Type: "about:blank", Title: "Internal Server Error", Status: 500},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toProblem(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toProblem(%v) = %+v, want %+v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = NewErrorHandler()
+	notFound := func(echo.Context) error {
+		return fmt.Errorf("failed to get task: %w", taskService.ErrTaskNotFound)
+	}
+	e.GET("/tasks/:id", notFound)
+	e.HEAD("/tasks/:id", notFound)
+	e.GET("/boom", func(echo.Context) error {
+		return errors.New("secret connection string")
+	})
+
+	t.Run("problem json", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/7", nil))
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", rec.Code)
+		}
+		if ct := rec.Header().Get(echo.HeaderContentType); ct != problemContentType {
+			t.Errorf("content type = %q, want %q", ct, problemContentType)
+		}
+		var got problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		want := problem{Type: "about:blank", Title: "Not Found", Status: 404, Detail: "task not found", Instance: "/tasks/7"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("body = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("internal error body has no details", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("status = %d, want 500", rec.Code)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := got["detail"]; ok {
+			t.Errorf("500 response leaks detail: %s", rec.Body)
+		}
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nope", nil))
+		if rec.Code != http.StatusNotFound || rec.Header().Get(echo.HeaderContentType) != problemContentType {
+			t.Errorf("got %d %q, want 404 problem+json", rec.Code, rec.Header().Get(echo.HeaderContentType))
+		}
+	})
+
+	t.Run("HEAD has no body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/tasks/7", nil))
+		if rec.Code != http.StatusNotFound || rec.Body.Len() != 0 {
+			t.Errorf("got %d with %d bytes, want 404 without body", rec.Code, rec.Body.Len())
+		}
+	})
+}
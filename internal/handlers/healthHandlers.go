@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HealthCheck - проверка одной зависимости, например базы данных
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthHandler отвечает на пробы оркестратора: /healthz и /readyz
+type HealthHandler struct {
+	checks []HealthCheck
+	// draining сообщает, что началась остановка и новых запросов слать не надо
+	draining func() bool
+	timeout  time.Duration
+}
+
+func NewHealthHandler(draining func() bool, timeout time.Duration, checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{
+		checks:   checks,
+		draining: draining,
+		timeout:  timeout,
+	}
+}
+
+type healthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+type checkResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Register вешает пробы прямо на echo, мимо strict-хендлеров и авторизации
+func (h *HealthHandler) Register(e *echo.Echo) {
+	e.GET("/healthz", h.Live)
+	e.GET("/readyz", h.Ready)
+}
+
+// Live отвечает, как только процесс поднялся. Зависимости тут не проверяем:
+// иначе оркестратор начнёт перезапускать под из-за упавшей базы
+func (h *HealthHandler) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, healthResponse{Status: statusOK})
+}
+
+// Ready проверяет все зависимости параллельно и отвечает 503, если хоть одна
+// не в порядке или сервер уже останавливается
+func (h *HealthHandler) Ready(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), h.timeout)
+	defer cancel()
+
+	response := healthResponse{Status: statusOK, Checks: map[string]checkResult{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func(check HealthCheck) {
+			defer wg.Done()
+			start := time.Now()
+			err := check.Check(ctx)
+			result := checkResult{
+				Status:    statusOK,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				result.Status = statusFail
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			response.Checks[check.Name] = result
+			if err != nil {
+				response.Status = statusFail
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	if h.draining() {
+		response.Status = statusFail
+		response.Checks["shutdown"] = checkResult{Status: statusFail, Error: "server is shutting down"}
+	}
+
+	status := http.StatusOK
+	if response.Status != statusOK {
+		status = http.StatusServiceUnavailable
+	}
+	return c.JSON(status, response)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"pet1/internal/taskService"
+	"pet1/internal/web/labels"
+)
+
+// LabelHandler обрабатывает метки задач. Метки живут в сервисе задач
+type LabelHandler struct {
+	Service *taskService.TaskService
+}
+
+func NewLabelHandler(service *taskService.TaskService) *LabelHandler {
+	return &LabelHandler{
+		Service: service,
+	}
+}
+
+// GetLabels возвращает метки вызывающего
+func (h *LabelHandler) GetLabels(ctx context.Context, _ labels.GetLabelsRequestObject) (labels.GetLabelsResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "LabelHandler.GetLabels")
+	defer span.End()
+
+	found, err := h.Service.ListLabels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	response := labels.GetLabels200JSONResponse{}
+	for _, label := range found {
+		response = append(response, toLabel(label))
+	}
+	return response, nil
+}
+
+// PostLabels создаёт метку. Занятое имя - 409
+func (h *LabelHandler) PostLabels(ctx context.Context, request labels.PostLabelsRequestObject) (labels.PostLabelsResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "LabelHandler.PostLabels")
+	defer span.End()
+
+	created, err := h.Service.CreateLabel(ctx, taskService.Label{
+		Name:  request.Body.Name,
+		Color: deref(request.Body.Color),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+	return labels.PostLabels201JSONResponse(toLabel(created)), nil
+}
+
+// PatchLabelsId переименовывает или перекрашивает метку. Чужая метка - 404
+func (h *LabelHandler) PatchLabelsId(ctx context.Context, request labels.PatchLabelsIdRequestObject) (labels.PatchLabelsIdResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "LabelHandler.PatchLabelsId")
+	defer span.End()
+
+	updated, err := h.Service.UpdateLabelByID(ctx, request.Id, taskService.Label{
+		Name:  deref(request.Body.Name),
+		Color: deref(request.Body.Color),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+	return labels.PatchLabelsId200JSONResponse(toLabel(updated)), nil
+}
+
+// DeleteLabelsId удаляет метку, задачи с ней остаются без неё
+func (h *LabelHandler) DeleteLabelsId(ctx context.Context, request labels.DeleteLabelsIdRequestObject) (labels.DeleteLabelsIdResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "LabelHandler.DeleteLabelsId")
+	defer span.End()
+
+	if err := h.Service.DeleteLabelByID(ctx, request.Id); err != nil {
+		return nil, fmt.Errorf("failed to delete label: %w", err)
+	}
+	return labels.DeleteLabelsId204Response{}, nil
+}
+
+// toLabel переводит метку сервиса в метку API
+func toLabel(label taskService.Label) labels.Label {
+	return labels.Label{
+		Id:        &label.ID,
+		Name:      label.Name,
+		Color:     label.Color,
+		CreatedAt: &label.CreatedAt,
+		UpdatedAt: &label.UpdatedAt,
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"pet1/internal/pagination"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pageResponse - страница записей с заголовками Link и X-Total-Count.
+// Сгенерированные ответы ставят заголовки всегда, даже пустыми, а нам нужно
+// не отдавать Link без соседних страниц и X-Total-Count, если его не считали
+type pageResponse[T any] struct {
+	items []T
+	link  string
+	total *int64
+}
+
+func newPageResponse[S, T any](page pagination.Page[S], convert func(S) T, path string, query url.Values) pageResponse[T] {
+	response := pageResponse[T]{items: make([]T, 0, len(page.Items)), total: page.Total}
+	for _, item := range page.Items {
+		response.items = append(response.items, convert(item))
+	}
+	response.link = pageLinks(path, query, page.NextCursor, page.PrevCursor)
+	return response
+}
+
+func (r pageResponse[T]) write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	if r.link != "" {
+		w.Header().Set("Link", r.link)
+	}
+	if r.total != nil {
+		w.Header().Set("X-Total-Count", strconv.FormatInt(*r.total, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(r.items)
+}
+
+// pageParams собирает параметры страницы из параметров запроса.
+// X-Total-Count считаем, пока клиент явно не попросил count=false
+func pageParams(limit *int, cursor *string, sort, order string, count *bool) pagination.Params {
+	return pagination.Params{
+		SortBy: sort,
+		Desc:   order == "desc",
+		Cursor: deref(cursor),
+		Limit:  deref(limit),
+		Count:  count == nil || *count,
+	}
+}
+
+// pageQuery - параметры запроса, общие для всех списков, для ссылок на соседние страницы.
+// Курсор сюда не входит, его подставляет pageLinks
+func pageQuery(limit *int, sort, order string, count *bool) url.Values {
+	query := url.Values{}
+	if limit != nil {
+		query.Set("limit", strconv.Itoa(*limit))
+	}
+	if sort != "" {
+		query.Set("sort", sort)
+	}
+	if order != "" {
+		query.Set("order", order)
+	}
+	if count != nil {
+		query.Set("count", strconv.FormatBool(*count))
+	}
+	return query
+}
+
+// pageLinks собирает заголовок Link (RFC 8288) со ссылками на соседние страницы.
+// Ссылки относительные: схему и хост за прокси сервер всё равно не знает наверняка
+func pageLinks(path string, query url.Values, nextCursor, prevCursor string) string {
+	var links []string
+	for _, link := range []struct{ rel, cursor string }{
+		{"next", nextCursor},
+		{"prev", prevCursor},
+	} {
+		if link.cursor == "" {
+			continue
+		}
+		q := url.Values{}
+		for key, values := range query {
+			q[key] = values
+		}
+		q.Set("cursor", link.cursor)
+		links = append(links, "<"+path+"?"+q.Encode()+`>; rel="`+link.rel+`"`)
+	}
+	return strings.Join(links, ", ")
+}
+
+// setTime добавляет в запрос момент времени в том же формате, в каком его ждёт API
+func setTime(query url.Values, key string, value *time.Time) {
+	if value != nil {
+		query.Set(key, value.Format(time.RFC3339Nano))
+	}
+}
+
+// setString добавляет в запрос непустую строку
+func setString(query url.Values, key string, value *string) {
+	if value != nil && *value != "" {
+		query.Set(key, *value)
+	}
+}
+
+// deref возвращает значение указателя или нулевое значение, если его нет
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"context"
+	"pet1/internal/apperr"
+	"pet1/internal/identity"
+	"pet1/internal/policy"
+)
+
+// errForbidden - роли вызывающего действие запрещено политикой
+var errForbidden = apperr.Forbidden("action is not allowed for your role")
+
+// can сверяется с политикой доступа от имени вызывающего из контекста
+func can(ctx context.Context, action policy.Action, ownerID uint) bool {
+	caller, ok := identity.FromContext(ctx)
+	return ok && policy.Can(caller, action, ownerID)
+}
+
+// canAny проверяет, что действие разрешено над ресурсами любого пользователя
+func canAny(ctx context.Context, action policy.Action) bool {
+	caller, ok := identity.FromContext(ctx)
+	return ok && policy.CanAny(caller, action)
+}
+
+// allowed проверяет, что роли вызывающего действие доступно хотя бы над своими ресурсами
+func allowed(ctx context.Context, action policy.Action) bool {
+	caller, ok := identity.FromContext(ctx)
+	return ok && policy.Allowed(caller, action)
+}
@@ -3,8 +3,14 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"pet1/internal/apperr"
+	"pet1/internal/policy"
 	"pet1/internal/taskService"
 	"pet1/internal/web/tasks"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // TaskHandler переименовываем для ясности
@@ -21,17 +27,21 @@ func NewTaskHandler(service *taskService.TaskService) *TaskHandler {
 
 // DeleteTasksId реализует удаление задачи по ID
 func (h *TaskHandler) DeleteTasksId(ctx context.Context, request tasks.DeleteTasksIdRequestObject) (tasks.DeleteTasksIdResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.DeleteTasksId")
+	defer span.End()
+
 	// Извлекаем ID задачи из запроса
 	id := request.Id
 
+	// Проверяем, что роли вызывающего вообще можно удалять задачи
+	if !allowed(ctx, policy.WriteTasks) {
+		return nil, errForbidden
+	}
+
 	// Вызываем сервис для удаления задачи
-	err := h.Service.DeleteTaskByID(id)
+	err := h.Service.DeleteTaskByID(ctx, id)
 	if err != nil {
-		if err.Error() == "task not found" || err.Error() == "no task was deleted" {
-			// Возвращаем 404 Not Found, если задача не найдена
-			return tasks.DeleteTasksId404Response{}, nil
-		}
-		// Возвращаем 500 Internal Server Error для других ошибок
+		// Статус (404, 500 и т.д.) по виду ошибки выберет общий обработчик ошибок
 		return nil, fmt.Errorf("failed to delete task: %w", err)
 	}
 
@@ -39,10 +49,18 @@ func (h *TaskHandler) DeleteTasksId(ctx context.Context, request tasks.DeleteTas
 	return tasks.DeleteTasksId204Response{}, nil
 }
 
-func (h *TaskHandler) PatchTasksId(_ context.Context, request tasks.PatchTasksIdRequestObject) (tasks.PatchTasksIdResponseObject, error) {
+func (h *TaskHandler) PatchTasksId(ctx context.Context, request tasks.PatchTasksIdRequestObject) (tasks.PatchTasksIdResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.PatchTasksId")
+	defer span.End()
+
 	// Извлекаем ID задачи из запроса
 	id := request.Id
 
+	// Проверяем, что роли вызывающего вообще можно менять задачи
+	if !allowed(ctx, policy.WriteTasks) {
+		return nil, errForbidden
+	}
+
 	// Извлекаем тело запроса, содержащее поля для обновления
 	body := request.Body
 
@@ -53,102 +71,389 @@ func (h *TaskHandler) PatchTasksId(_ context.Context, request tasks.PatchTasksId
 		taskToUpdate.Task = *body.Task
 	}
 
-	if body.IsDone != nil {
-		taskToUpdate.IsDone = *body.IsDone
+	// Нулевое время для сервиса значит «убрать»
+	taskToUpdate.DueAt = body.DueAt
+	if deref(body.ClearDueAt) {
+		taskToUpdate.DueAt = &time.Time{}
+	}
+	taskToUpdate.RemindAt = body.RemindAt
+	if deref(body.ClearRemindAt) {
+		taskToUpdate.RemindAt = &time.Time{}
+	}
+
+	// Состояние, приоритет и метки меняются отдельно от полей задачи: nil значит «не менять»
+	update := taskService.TaskUpdate{
+		IsDone:          body.IsDone,
+		Priority:        body.Priority,
+		ParentID:        body.ParentId,
+		AttachLabels:    deref(body.AddLabels),
+		DetachLabels:    deref(body.RemoveLabels),
+		EstimateMinutes: body.EstimateMinutes,
+		ClearEstimate:   deref(body.ClearEstimate),
+		Force:           deref(body.Force),
+	}
+	// Как и со временем, ноль для сервиса значит «убрать»
+	if deref(body.ClearParentId) {
+		update.ParentID = new(uint)
 	}
 
 	// Вызываем сервис для обновления задачи
-	updatedTask, err := h.Service.UpdateTaskByID(id, taskToUpdate)
+	updatedTask, err := h.Service.UpdateTaskByID(ctx, id, taskToUpdate, update)
 	if err != nil {
-		if err.Error() == "task not found" {
-			// Возвращаем 404 Not Found, если задача не найдена
-			return tasks.PatchTasksId404Response{}, nil
-		}
-		// Возвращаем 500 Internal Server Error для других ошибок
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
-	// Создаём объект ответа с обновлённой задачей
-	responseTask := tasks.Task{
-		Id:     &updatedTask.ID,
-		Task:   updatedTask.Task,
-		IsDone: updatedTask.IsDone,
-		UserId: updatedTask.UserID,
-	}
-
 	// Возвращаем 200 OK с обновлённой задачей
-	return tasks.PatchTasksId200JSONResponse(responseTask), nil
+	return tasks.PatchTasksId200JSONResponse(toTask(updatedTask)), nil
 }
 
-func (h *TaskHandler) GetTasks(_ context.Context, _ tasks.GetTasksRequestObject) (tasks.GetTasksResponseObject, error) {
-	// Получение всех задач из сервиса
-	allTasks, err := h.Service.GetAllTasks()
+func (h *TaskHandler) GetTasks(ctx context.Context, request tasks.GetTasksRequestObject) (tasks.GetTasksResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.GetTasks")
+	defer span.End()
+
+	// Переводим параметры запроса в параметры сервиса. Всё, что не указано, остаётся нулевым
+	params := request.Params
+	sort, order := string(deref(params.Sort)), string(deref(params.Order))
+	location, err := loadLocation(params.Tz)
 	if err != nil {
 		return nil, err
 	}
+	listParams := taskService.ListParams{
+		TaskFilter: taskService.TaskFilter{
+			UserID:        deref(params.UserId),
+			IsDone:        params.IsDone,
+			CreatedAfter:  params.CreatedAfter,
+			CreatedBefore: params.CreatedBefore,
+			UpdatedAfter:  params.UpdatedAfter,
+			UpdatedBefore: params.UpdatedBefore,
+			Labels:        deref(params.Labels),
+			AllLabels:     deref(params.LabelsMatch) == tasks.All,
+			Blocked:       params.Blocked,
+		},
+		Params:   pageParams(params.Limit, params.Cursor, sort, order, params.Count),
+		Due:      string(deref(params.Due)),
+		Location: location,
+	}
 
-	// Создаем переменную респон типа 200джейсонРеспонс
-	// Которую мы потом передадим в качестве ответа
-	response := tasks.GetTasks200JSONResponse{}
+	page, err := h.Service.ListTasks(ctx, listParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
 
-	// Заполняем слайс response всеми задачами из БД
-	for _, tsk := range allTasks {
-		task := tasks.Task{
-			Id:     &tsk.ID,
-			Task:   tsk.Task,
-			IsDone: tsk.IsDone,
-			UserId: tsk.UserID,
+	// Ссылки на соседние страницы повторяют запрос, кроме курсора
+	query := pageQuery(params.Limit, sort, order, params.Count)
+	if params.IsDone != nil {
+		query.Set("is_done", strconv.FormatBool(*params.IsDone))
+	}
+	if params.UserId != nil {
+		query.Set("user_id", strconv.FormatUint(uint64(*params.UserId), 10))
+	}
+	setTime(query, "created_after", params.CreatedAfter)
+	setTime(query, "created_before", params.CreatedBefore)
+	setTime(query, "updated_after", params.UpdatedAfter)
+	setTime(query, "updated_before", params.UpdatedBefore)
+	if params.Due != nil {
+		query.Set("due", string(*params.Due))
+	}
+	setString(query, "tz", params.Tz)
+	if params.Labels != nil {
+		ids := make([]string, 0, len(*params.Labels))
+		for _, id := range *params.Labels {
+			ids = append(ids, strconv.FormatUint(uint64(id), 10))
 		}
-		response = append(response, task)
+		query.Set("labels", strings.Join(ids, ","))
+	}
+	if params.LabelsMatch != nil {
+		query.Set("labels_match", string(*params.LabelsMatch))
+	}
+	if params.Blocked != nil {
+		query.Set("blocked", strconv.FormatBool(*params.Blocked))
+	}
+
+	return tasksPageResponse{newPageResponse(page, toTask, "/tasks", query)}, nil
+}
+
+// loadLocation находит часовой пояс по имени из базы IANA, пустое имя - UTC
+func loadLocation(name *string) (*time.Location, error) {
+	if name == nil || *name == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(*name)
+	if err != nil {
+		return nil, apperr.Invalid("tz", "unknown time zone")
 	}
+	return location, nil
+}
+
+// tasksPageResponse - ответ GetTasks: страница задач с заголовками Link и X-Total-Count
+type tasksPageResponse struct{ pageResponse[tasks.Task] }
+
+func (response tasksPageResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
 
-	// САМОЕ ПРЕКРАСНОЕ. Возвращаем просто респонс и nil!
+// GetTasksSearch реализует поиск по тексту задач вызывающего
+func (h *TaskHandler) GetTasksSearch(ctx context.Context, request tasks.GetTasksSearchRequestObject) (tasks.GetTasksSearchResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.GetTasksSearch")
+	defer span.End()
+
+	found, err := h.Service.SearchTasks(ctx, taskService.SearchParams{
+		Text:  request.Params.Q,
+		Limit: deref(request.Params.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	response := tasks.GetTasksSearch200JSONResponse{}
+	for _, result := range found {
+		response = append(response, tasks.TaskSearchResult{
+			Task:     toTask(result.Task),
+			Rank:     result.Rank,
+			Headline: result.Headline,
+		})
+	}
 	return response, nil
 }
 
-func (h *TaskHandler) PostTasks(_ context.Context, request tasks.PostTasksRequestObject) (tasks.PostTasksResponseObject, error) {
+// toTask переводит задачу сервиса в задачу API
+func toTask(tsk taskService.Task) tasks.Task {
+	return tasks.Task{
+		Id:              &tsk.ID,
+		Task:            tsk.Task,
+		IsDone:          tsk.IsDone,
+		UserId:          tsk.UserID,
+		ParentId:        tsk.ParentID,
+		DueAt:           tsk.DueAt,
+		RemindAt:        tsk.RemindAt,
+		Priority:        &tsk.Priority,
+		Labels:          toTaskLabels(tsk.Labels),
+		CreatedAt:       &tsk.CreatedAt,
+		UpdatedAt:       &tsk.UpdatedAt,
+		EstimateMinutes: tsk.EstimateMinutes,
+		Blocked:         &tsk.Blocked,
+	}
+}
+
+// toTaskLabels переводит метки задачи в метки API. У задачи без меток - пустой список
+func toTaskLabels(labels []taskService.Label) *[]tasks.Label {
+	result := make([]tasks.Label, 0, len(labels))
+	for _, label := range labels {
+		result = append(result, tasks.Label(toLabel(label)))
+	}
+	return &result
+}
+
+func (h *TaskHandler) PostTasks(ctx context.Context, request tasks.PostTasksRequestObject) (tasks.PostTasksResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.PostTasks")
+	defer span.End()
+
 	taskRequest := request.Body
 	taskToCreate := taskService.Task{
-		Task:   taskRequest.Task,
-		IsDone: taskRequest.IsDone,
-		UserID: taskRequest.UserId,
+		Task:     taskRequest.Task,
+		IsDone:   taskRequest.IsDone,
+		DueAt:    taskRequest.DueAt,
+		RemindAt: taskRequest.RemindAt,
+		ParentID: taskRequest.ParentId,
+		Priority: taskService.PriorityDefault,
+		// Отрицательную оценку отклонит сервис
+		EstimateMinutes: taskRequest.EstimateMinutes,
+	}
+	if taskRequest.Priority != nil {
+		taskToCreate.Priority = *taskRequest.Priority
 	}
-	createdTask, err := h.Service.CreateTask(taskToCreate)
+	// Сервису достаточно ID меток, остальное он проверит и загрузит сам
+	for _, id := range deref(taskRequest.Labels) {
+		taskToCreate.Labels = append(taskToCreate.Labels, taskService.Label{ID: id})
+	}
+	// Если владелец не указан, сервис назначит им вызывающего
+	if taskRequest.UserId != nil {
+		taskToCreate.UserID = *taskRequest.UserId
+	}
+	createdTask, err := h.Service.CreateTask(ctx, taskToCreate)
 
 	if err != nil {
-		return nil, err
+		// ErrForbidden, если задачу создают другому пользователю, превратится в 403
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	return tasks.PostTasks201JSONResponse(toTask(createdTask)), nil
+}
+
+// GetUsersTasks реализует получение задач пользователя постранично.
+// Сервис сначала проверяет, что пользователь есть и его задачи видны вызывающему, иначе 404
+func (h *TaskHandler) GetUsersTasks(ctx context.Context, request tasks.GetUsersIdTasksRequestObject) (tasks.GetUsersIdTasksResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.GetUsersTasks")
+	defer span.End()
+
+	params := request.Params
+	sort, order := string(deref(params.Sort)), string(deref(params.Order))
+	page, err := h.Service.ListTasks(ctx, taskService.ListParams{
+		TaskFilter: taskService.TaskFilter{
+			UserID: request.Id,
+			IsDone: params.IsDone,
+		},
+		Params: pageParams(params.Limit, params.Cursor, sort, order, params.Count),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user tasks: %w", err)
+	}
+
+	query := pageQuery(params.Limit, sort, order, params.Count)
+	if params.IsDone != nil {
+		query.Set("is_done", strconv.FormatBool(*params.IsDone))
+	}
+	path := "/users/" + strconv.FormatUint(uint64(request.Id), 10) + "/tasks"
+	return userTasksPageResponse{newPageResponse(page, toTaskWithoutUserID, path, query)}, nil
+}
+
+func (h *TaskHandler) GetUsersIdTasks(ctx context.Context, request tasks.GetUsersIdTasksRequestObject) (tasks.GetUsersIdTasksResponseObject, error) {
+	return h.GetUsersTasks(ctx, request)
+}
+
+// userTasksPageResponse - ответ GetUsersIdTasks: страница задач с заголовками Link и X-Total-Count
+type userTasksPageResponse struct {
+	pageResponse[tasks.TaskWithoutUserID]
+}
+
+func (response userTasksPageResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	return response.write(w)
+}
+
+// toTaskWithoutUserID переводит задачу сервиса в задачу API без владельца: он и так в пути
+func toTaskWithoutUserID(tsk taskService.Task) tasks.TaskWithoutUserID {
+	return tasks.TaskWithoutUserID{
+		Id:              &tsk.ID,
+		Task:            tsk.Task,
+		IsDone:          tsk.IsDone,
+		ParentId:        tsk.ParentID,
+		DueAt:           tsk.DueAt,
+		RemindAt:        tsk.RemindAt,
+		Priority:        &tsk.Priority,
+		Labels:          toTaskLabels(tsk.Labels),
+		CreatedAt:       &tsk.CreatedAt,
+		UpdatedAt:       &tsk.UpdatedAt,
+		EstimateMinutes: tsk.EstimateMinutes,
+		Blocked:         &tsk.Blocked,
 	}
+}
+
+// GetTasksIdSubtasks возвращает прямые подзадачи с прогрессом каждой
+func (h *TaskHandler) GetTasksIdSubtasks(ctx context.Context, request tasks.GetTasksIdSubtasksRequestObject) (tasks.GetTasksIdSubtasksResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.GetTasksIdSubtasks")
+	defer span.End()
 
-	response := tasks.PostTasks201JSONResponse{
-		Id:     &createdTask.ID,
-		Task:   createdTask.Task,
-		IsDone: createdTask.IsDone,
-		UserId: createdTask.UserID,
+	subtasks, err := h.Service.GetSubtasks(ctx, request.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	response := tasks.GetTasksIdSubtasks200JSONResponse{}
+	for _, subtask := range subtasks {
+		response = append(response, toTaskNode(subtask))
 	}
 	return response, nil
 }
 
-// GetUsersTasks реализует получение задач пользователя
-func (h *TaskHandler) GetUsersTasks(_ context.Context, request tasks.GetUsersIdTasksRequestObject) (tasks.GetUsersIdTasksResponseObject, error) {
-	userTasks, err := h.Service.GetTasksByUserID(request.Id)
+// GetTasksIdTree возвращает задачу со всеми подзадачами деревом
+func (h *TaskHandler) GetTasksIdTree(ctx context.Context, request tasks.GetTasksIdTreeRequestObject) (tasks.GetTasksIdTreeResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.GetTasksIdTree")
+	defer span.End()
+
+	tree, err := h.Service.GetTaskTree(ctx, request.Id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user tasks: %w", err)
+		return nil, fmt.Errorf("failed to get task tree: %w", err)
 	}
+	return tasks.GetTasksIdTree200JSONResponse(toTaskNode(tree)), nil
+}
 
-	response := tasks.GetUsersIdTasks200JSONResponse{}
-	for _, tsk := range userTasks {
-		task := tasks.Task{
-			Id:     &tsk.ID,
-			Task:   tsk.Task,
-			IsDone: tsk.IsDone,
-			UserId: tsk.UserID,
+// toTaskNode переводит узел дерева в API. Узел без Children (так отдаёт
+// GetSubtasks) приходит без children, лист дерева - с пустым списком
+func toTaskNode(node taskService.TaskNode) tasks.TaskNode {
+	result := tasks.TaskNode{
+		Task: toTask(node.Task),
+		Progress: tasks.TaskProgress{
+			Total:   node.Progress.Total,
+			Done:    node.Progress.Done,
+			Percent: node.Progress.Percent,
+		},
+	}
+	if node.Children != nil {
+		children := make([]tasks.TaskNode, 0, len(node.Children))
+		for _, child := range node.Children {
+			children = append(children, toTaskNode(child))
 		}
-		response = append(response, task)
+		result.Children = &children
 	}
+	return result
+}
+
+// GetTasksIdBlockers возвращает задачи, которые блокируют задачу
+func (h *TaskHandler) GetTasksIdBlockers(ctx context.Context, request tasks.GetTasksIdBlockersRequestObject) (tasks.GetTasksIdBlockersResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.GetTasksIdBlockers")
+	defer span.End()
+
+	blockers, err := h.Service.GetBlockers(ctx, request.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockers: %w", err)
+	}
+
+	return tasks.GetTasksIdBlockers200JSONResponse(toTasks(blockers)), nil
+}
+
+// PutTasksIdBlockersBlockerId добавляет зависимость. Цикл сервис отклонит с 409
+func (h *TaskHandler) PutTasksIdBlockersBlockerId(ctx context.Context, request tasks.PutTasksIdBlockersBlockerIdRequestObject) (tasks.PutTasksIdBlockersBlockerIdResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.PutTasksIdBlockersBlockerId")
+	defer span.End()
+
+	if !allowed(ctx, policy.WriteTasks) {
+		return nil, errForbidden
+	}
+	if err := h.Service.AddBlocker(ctx, request.Id, request.BlockerId); err != nil {
+		return nil, fmt.Errorf("failed to add blocker: %w", err)
+	}
+	return tasks.PutTasksIdBlockersBlockerId204Response{}, nil
+}
+
+// DeleteTasksIdBlockersBlockerId убирает зависимость
+func (h *TaskHandler) DeleteTasksIdBlockersBlockerId(ctx context.Context, request tasks.DeleteTasksIdBlockersBlockerIdRequestObject) (tasks.DeleteTasksIdBlockersBlockerIdResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.DeleteTasksIdBlockersBlockerId")
+	defer span.End()
 
+	if !allowed(ctx, policy.WriteTasks) {
+		return nil, errForbidden
+	}
+	if err := h.Service.RemoveBlocker(ctx, request.Id, request.BlockerId); err != nil {
+		return nil, fmt.Errorf("failed to remove blocker: %w", err)
+	}
+	return tasks.DeleteTasksIdBlockersBlockerId204Response{}, nil
+}
+
+// GetTasksPlan возвращает порядок выполнения задач и, если есть оценки, критический путь
+func (h *TaskHandler) GetTasksPlan(ctx context.Context, request tasks.GetTasksPlanRequestObject) (tasks.GetTasksPlanResponseObject, error) {
+	ctx, span := tracer.Start(ctx, "TaskHandler.GetTasksPlan")
+	defer span.End()
+
+	plan, err := h.Service.PlanTasks(ctx, deref(request.Params.UserId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan tasks: %w", err)
+	}
+
+	response := tasks.GetTasksPlan200JSONResponse{Order: toTasks(plan.Order)}
+	if plan.CriticalPath != nil {
+		response.CriticalPath = &tasks.CriticalPath{
+			Tasks:           toTasks(plan.CriticalPath),
+			EstimateMinutes: plan.EstimateMinutes,
+		}
+	}
 	return response, nil
 }
 
-func (h *TaskHandler) GetUsersIdTasks(ctx context.Context, request tasks.GetUsersIdTasksRequestObject) (tasks.GetUsersIdTasksResponseObject, error) {
-	return h.GetUsersTasks(ctx, request)
+// toTasks переводит список задач сервиса в API, пустой - в пустой
+func toTasks(list []taskService.Task) []tasks.Task {
+	result := make([]tasks.Task, 0, len(list))
+	for _, tsk := range list {
+		result = append(result, toTask(tsk))
+	}
+	return result
 }
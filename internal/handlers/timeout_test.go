@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pet1/internal/db/dbtest"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// slowSQL считает без конца, пока запрос не прервут. Выполняется через Exec:
+// драйвер SQLite следит за контекстом, пока шагает запрос в Exec, а строки SELECT
+// читаются в rows.Next уже без этого
+const slowSQL = `WITH RECURSIVE r(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r) SELECT count(*) FROM r`
+
+func TestContextTimeout(t *testing.T) {
+	conn := dbtest.SQLite(t)
+
+	e := echo.New()
+	e.HTTPErrorHandler = NewErrorHandler()
+	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Timeout:      50 * time.Millisecond,
+		ErrorHandler: TimeoutError,
+	}))
+	e.GET("/slow", func(c echo.Context) error {
+		if err := conn.WithContext(c.Request().Context()).Exec(slowSQL).Error; err != nil {
+			return fmt.Errorf("failed to count: %w", err)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/broken", func(echo.Context) error {
+		return errors.New("broken before the deadline")
+	})
+
+	t.Run("slow query is aborted with 503", func(t *testing.T) {
+		start := time.Now()
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("request took %v, the query was not aborted at the deadline", elapsed)
+		}
+		if rec.Code != http.StatusServiceUnavailable || rec.Header().Get(echo.HeaderContentType) != problemContentType {
+			t.Fatalf("got %d %q, want 503 problem+json", rec.Code, rec.Header().Get(echo.HeaderContentType))
+		}
+		var got problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != http.StatusServiceUnavailable || got.Detail != "" {
+			t.Errorf("body = %+v, want 503 without details", got)
+		}
+	})
+
+	t.Run("other errors are not masked", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/broken", nil))
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want 500", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,6 @@
+package handlers
+
+import "go.opentelemetry.io/otel"
+
+// tracer - спаны хендлеров, дочерние к спану запроса из otelecho
+var tracer = otel.Tracer("pet1/internal/handlers")
@@ -3,6 +3,11 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"pet1/internal/apperr"
+	"pet1/internal/identity"
+	"pet1/internal/pagination"
+	"pet1/internal/policy"
 	"pet1/internal/userService"
 	"pet1/internal/web/users"
 )
@@ -18,57 +23,100 @@ func NewUserHandler(service *userService.UserService) *UserHandler {
 	}
 }
 
-// GetUsers реализует получение всех пользователей
-func (h *UserHandler) GetUsers(_ context.Context, _ users.GetUsersRequestObject) (users.GetUsersResponseObject, error) {
-	allUsers, err := h.Service.GetAllUsers()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get users: %w", err)
+// GetUsers реализует получение постранично пользователей, которых видит вызывающий.
+// Кому не разрешено читать всех, тот видит только себя
+func (h *UserHandler) GetUsers(ctx context.Context, request users.GetUsersRequestObject) (users.GetUsersResponseObject, error) {
+	params := request.Params
+	sort, order := string(deref(params.Sort)), string(deref(params.Order))
+	listParams := userService.ListParams{
+		UserFilter: userService.UserFilter{
+			EmailPrefix:   deref(params.EmailPrefix),
+			EmailContains: deref(params.EmailContains),
+			CreatedAfter:  params.CreatedAfter,
+			CreatedBefore: params.CreatedBefore,
+		},
+		Params: pageParams(params.Limit, params.Cursor, sort, order, params.Count),
 	}
 
-	response := users.GetUsers200JSONResponse{}
-	for _, usr := range allUsers {
-		user := users.User{
-			Id:       &usr.ID,
-			Email:    &usr.Email,
-			Password: &usr.Password,
+	query := pageQuery(params.Limit, sort, order, params.Count)
+	setString(query, "email_prefix", params.EmailPrefix)
+	setString(query, "email_contains", params.EmailContains)
+	setTime(query, "created_after", params.CreatedAfter)
+	setTime(query, "created_before", params.CreatedBefore)
+
+	if !canAny(ctx, policy.ReadUsers) {
+		caller, ok := identity.FromContext(ctx)
+		if !ok || !can(ctx, policy.ReadUsers, caller.UserID) {
+			// Читать некого - пустая страница, а не 403: список просто пуст
+			empty := pagination.Page[userService.User]{}
+			if listParams.Count {
+				empty.Total = new(int64)
+			}
+			return usersPageResponse{newPageResponse(empty, toUserResponse, "/users", query)}, nil
 		}
-		response = append(response, user)
+		listParams.ID = caller.UserID
+	}
+
+	page, err := h.Service.ListUsers(ctx, listParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
+	return usersPageResponse{newPageResponse(page, toUserResponse, "/users", query)}, nil
+}
+
+// usersPageResponse - ответ GetUsers: страница пользователей с заголовками Link и X-Total-Count
+type usersPageResponse struct{ pageResponse[users.User] }
 
-	return response, nil
+func (response usersPageResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
+	return response.write(w)
 }
 
 // PostUsers реализует создание нового пользователя
-func (h *UserHandler) PostUsers(_ context.Context, request users.PostUsersRequestObject) (users.PostUsersResponseObject, error) {
+func (h *UserHandler) PostUsers(ctx context.Context, request users.PostUsersRequestObject) (users.PostUsersResponseObject, error) {
+	if !allowed(ctx, policy.CreateUsers) {
+		return nil, errForbidden
+	}
+
 	userRequest := request.Body
 	userToCreate := userService.User{
-		Email:    *userRequest.Email,
-		Password: *userRequest.Password,
+		Email: userRequest.Email,
+	}
+	if userRequest.Password != nil {
+		userToCreate.Password = *userRequest.Password
+	}
+	if userRequest.Role != nil {
+		role := identity.Role(*userRequest.Role)
+		if !role.Valid() {
+			return nil, apperr.Invalid("role", "unknown role")
+		}
+		if !allowed(ctx, policy.ManageRoles) {
+			return nil, errForbidden
+		}
+		userToCreate.Role = role
 	}
 
-	createdUser, err := h.Service.CreateUser(userToCreate)
+	createdUser, err := h.Service.CreateUser(ctx, userToCreate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	response := users.PostUsers201JSONResponse{
-		Id:       &createdUser.ID,
-		Email:    &createdUser.Email,
-		Password: &createdUser.Password,
-	}
-
-	return response, nil
+	return users.PostUsers201JSONResponse(toUserResponse(createdUser)), nil
 }
 
 // DeleteUsersId реализует удаление пользователя по ID
-func (h *UserHandler) DeleteUsersId(_ context.Context, request users.DeleteUsersIdRequestObject) (users.DeleteUsersIdResponseObject, error) {
+func (h *UserHandler) DeleteUsersId(ctx context.Context, request users.DeleteUsersIdRequestObject) (users.DeleteUsersIdResponseObject, error) {
 	id := request.Id
 
-	err := h.Service.DeleteUserByID(id)
-	if err != nil {
-		if err.Error() == "user not found" || err.Error() == "no user was deleted" {
-			return users.DeleteUsersId404Response{}, nil
+	if !can(ctx, policy.WriteUsers, id) {
+		// Тем, кто этого пользователя даже не видит, не раскрываем, что он существует
+		if !can(ctx, policy.ReadUsers, id) {
+			return nil, userService.ErrUserNotFound
 		}
+		return nil, errForbidden
+	}
+
+	err := h.Service.DeleteUserByID(ctx, id)
+	if err != nil {
 		return nil, fmt.Errorf("failed to delete user: %w", err)
 	}
 
@@ -76,10 +124,17 @@ func (h *UserHandler) DeleteUsersId(_ context.Context, request users.DeleteUsers
 }
 
 // PatchUsersId реализует обновление пользователя по ID
-func (h *UserHandler) PatchUsersId(_ context.Context, request users.PatchUsersIdRequestObject) (users.PatchUsersIdResponseObject, error) {
+func (h *UserHandler) PatchUsersId(ctx context.Context, request users.PatchUsersIdRequestObject) (users.PatchUsersIdResponseObject, error) {
 	id := request.Id
 	body := request.Body
 
+	if !can(ctx, policy.WriteUsers, id) {
+		if !can(ctx, policy.ReadUsers, id) {
+			return nil, userService.ErrUserNotFound
+		}
+		return nil, errForbidden
+	}
+
 	userToUpdate := userService.User{}
 
 	if body.Email != nil {
@@ -88,20 +143,33 @@ func (h *UserHandler) PatchUsersId(_ context.Context, request users.PatchUsersId
 	if body.Password != nil {
 		userToUpdate.Password = *body.Password
 	}
+	if body.Role != nil {
+		role := identity.Role(*body.Role)
+		if !role.Valid() {
+			return nil, apperr.Invalid("role", "unknown role")
+		}
+		if !allowed(ctx, policy.ManageRoles) {
+			return nil, errForbidden
+		}
+		userToUpdate.Role = role
+	}
 
-	updatedUser, err := h.Service.UpdateUserByID(id, userToUpdate)
+	updatedUser, err := h.Service.UpdateUserByID(ctx, id, userToUpdate)
 	if err != nil {
-		if err.Error() == "user not found" {
-			return users.PatchUsersId404Response{}, nil
-		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	response := users.PatchUsersId200JSONResponse{
-		Id:       &updatedUser.ID,
-		Email:    &updatedUser.Email,
-		Password: &updatedUser.Password,
-	}
+	return users.PatchUsersId200JSONResponse(toUserResponse(updatedUser)), nil
+}
 
-	return response, nil
+// toUserResponse собирает ответ API из модели пользователя.
+// Хеш пароля сюда не попадает ни при каких условиях
+func toUserResponse(usr userService.User) users.User {
+	return users.User{
+		Id:        &usr.ID,
+		Email:     usr.Email,
+		Role:      users.Role(usr.Role),
+		CreatedAt: &usr.CreatedAt,
+		UpdatedAt: &usr.UpdatedAt,
+	}
 }
@@ -0,0 +1,40 @@
+package identity
+
+import "context"
+
+// Role - роль пользователя
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleMember   Role = "member"
+	RoleReadOnly Role = "readonly"
+)
+
+// Valid сообщает, известна ли нам такая роль
+func (r Role) Valid() bool {
+	switch r {
+	case RoleAdmin, RoleMember, RoleReadOnly:
+		return true
+	}
+	return false
+}
+
+// Caller - тот, от чьего имени пришёл запрос
+type Caller struct {
+	UserID uint
+	Role   Role
+}
+
+type callerKey struct{}
+
+// NewContext кладёт вызывающего в контекст запроса
+func NewContext(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// FromContext достаёт вызывающего из контекста. ok = false, если запрос анонимный
+func FromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// operationKey - ключ в echo.Context, под которым strict-middleware оставляет operationID
+const operationKey = "metrics.operation"
+
+// otherOperation - метка для запросов, до которых strict-хендлер не дошёл:
+// неизвестные маршруты, ошибки разбора тела, служебные эндпоинты
+const otherOperation = "other"
+
+// Metrics держит свой реестр метрик, чтобы /metrics отдавал только то,
+// что мы сами зарегистрировали, плюс стандартные метрики рантайма
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	dbDuration   *prometheus.HistogramVec
+
+	tasksCreated   prometheus.Counter
+	tasksCompleted prometheus.Counter
+	remindersSent  prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		// Метки по operationID из OpenAPI, а не по пути: /tasks/1, /tasks/2... раздули бы число рядов
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by OpenAPI operation ID, method and status code.",
+		}, []string{"operation", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by OpenAPI operation ID.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation", "method"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Database query latency by gorm operation and table.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"operation", "table"}),
+		tasksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_created_total",
+			Help: "Tasks created.",
+		}),
+		tasksCompleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_completed_total",
+			Help: "Tasks switched from not done to done.",
+		}),
+		remindersSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "task_reminders_sent_total",
+			Help: "Task reminders sent by the reminder scheduler.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.dbDuration,
+		m.tasksCreated,
+		m.tasksCompleted,
+		m.remindersSent,
+	)
+	return m
+}
+
+// Handler отдаёт метрики в формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB добавляет статистику пула соединений sql.DB
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Middleware - echo-middleware, которое считает запросы и время ответа.
+// Ставится через e.Use, чтобы видеть и ответы общего обработчика ошибок.
+// Ошибка после подсчёта уходит дальше как есть: внешним middleware, например логу
+// запросов, она тоже нужна, а ответ уже записан, и повторно обработчик его не пишет
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// Как и middleware.Logger: отдаём ошибку обработчику сразу, чтобы узнать итоговый статус
+				c.Error(err)
+			}
+
+			operation, ok := c.Get(operationKey).(string)
+			if !ok {
+				operation = otherOperation
+			}
+			method := c.Request().Method
+			code := strconv.Itoa(c.Response().Status)
+			m.httpRequests.WithLabelValues(operation, method, code).Inc()
+			m.httpDuration.WithLabelValues(operation, method).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// OperationMiddleware - strict-middleware, которое запоминает operationID текущего запроса
+func (m *Metrics) OperationMiddleware() strictecho.StrictEchoMiddlewareFunc {
+	return func(f strictecho.StrictEchoHandlerFunc, operationID string) strictecho.StrictEchoHandlerFunc {
+		return func(ctx echo.Context, request interface{}) (interface{}, error) {
+			ctx.Set(operationKey, operationID)
+			return f(ctx, request)
+		}
+	}
+}
+
+// TaskCreated и TaskCompleted реализуют taskService.Metrics
+func (m *Metrics) TaskCreated() {
+	m.tasksCreated.Inc()
+}
+
+func (m *Metrics) TaskCompleted() {
+	m.tasksCompleted.Inc()
+}
+
+// RemindersSent реализует reminder.Metrics
+func (m *Metrics) RemindersSent(n int) {
+	m.remindersSent.Add(float64(n))
+}
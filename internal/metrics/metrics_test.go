@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pet1/internal/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TestMiddlewareKeepsError - метрики стоят внутри лога запросов, как в main.go:
+// ошибка хендлера должна дойти до лога, а ответ - записаться один раз
+func TestMiddlewareKeepsError(t *testing.T) {
+	var logs bytes.Buffer
+	m := New()
+
+	e := echo.New()
+	e.Use(logging.RequestLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+	e.Use(m.Middleware())
+	e.GET("/missing", func(echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "no such thing")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+	if n := strings.Count(rec.Body.String(), "no such thing"); n != 1 {
+		t.Errorf("error response written %d times: %s", n, rec.Body.String())
+	}
+
+	var line struct {
+		Status int    `json:"status"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(logs.Bytes(), &line); err != nil {
+		t.Fatalf("request log %q: %v", logs.String(), err)
+	}
+	if line.Status != http.StatusNotFound || !strings.Contains(line.Error, "no such thing") {
+		t.Errorf("request log = %+v, want status 404 with the handler error", line)
+	}
+
+	scrape := httptest.NewRecorder()
+	m.Handler().ServeHTTP(scrape, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(scrape.Body)
+	if want := `http_requests_total{code="404",method="GET",operation="other"} 1`; !strings.Contains(string(body), want) {
+		t.Errorf("metrics have no %s", want)
+	}
+}
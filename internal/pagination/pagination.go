@@ -0,0 +1,263 @@
+// Package pagination - постраничная выдача по ключу (keyset) с непрозрачными курсорами.
+// Страница продолжается от позиции последней записи, а не по OFFSET: так следующая
+// страница стоит столько же, сколько первая, и не съезжает, если между запросами
+// кто-то добавил или удалил записи
+package pagination
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"pet1/internal/apperr"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultLimit - размер страницы, если клиент его не указал
+	DefaultLimit = 20
+	// MaxLimit - больше за раз не отдаём, сколько ни проси
+	MaxLimit = 100
+)
+
+// SortByID - сортировка по ID, есть у всех списков и используется по умолчанию
+const SortByID = "id"
+
+// SortField - поле сортировки помимо id. Numeric - значение поля в Position
+// лежит в Number, иначе в Time
+type SortField struct {
+	Name    string
+	Numeric bool
+}
+
+// Params - страница в том виде, в каком её запросил клиент
+type Params struct {
+	// SortBy - поле сортировки, пустое - id
+	SortBy string
+	Desc   bool
+	// Cursor - курсор из предыдущей страницы, пустой - первая страница
+	Cursor string
+	// Limit - размер страницы, 0 - DefaultLimit
+	Limit int
+	// Count - считать ли, сколько всего записей под фильтром. На больших выборках это дорого
+	Count bool
+}
+
+// Position - место записи в сортировке: значение поля сортировки и ID
+// для записей с одинаковым значением. Из Time и Number заполнено только то,
+// что нужно полю сортировки, при сортировке по id - ни то, ни другое
+type Position struct {
+	Time   time.Time
+	Number int64
+	ID     uint
+}
+
+// Query - запрос страницы к репозиторию
+type Query struct {
+	SortBy string
+	// Numeric - значение поля сортировки числовое, см. SortField
+	Numeric bool
+	Desc    bool
+	// After - позиция, после которой начинается страница. nil - с самого начала
+	After *Position
+	// Backward - идти от After в обратную сторону, за предыдущей страницей.
+	// Репозиторий тогда отдаёт записи в порядке обхода, то есть задом наперёд
+	Backward bool
+	// Limit - сколько записей взять: на одну больше страницы, чтобы узнать, есть ли что-то дальше
+	Limit int
+}
+
+// Page - страница записей и курсоры соседних страниц. Пустой курсор - страницы нет
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+	// Total - сколько всего записей под фильтром, nil - не считали
+	Total *int64
+}
+
+// cursor - содержимое курсора. Клиенту он отдаётся как base64 от JSON
+// и разбирать его не должен: формат может поменяться
+type cursor struct {
+	SortBy   string     `json:"s"`
+	Desc     bool       `json:"d,omitempty"`
+	Time     *time.Time `json:"t,omitempty"`
+	Number   *int64     `json:"n,omitempty"`
+	ID       uint       `json:"i"`
+	Backward bool       `json:"b,omitempty"`
+}
+
+var errInvalidCursor = apperr.Invalid("cursor", "is malformed or does not match sort and order")
+
+// NewQuery проверяет параметры клиента и превращает их в запрос к репозиторию.
+// sortFields - поля, по которым разрешено сортировать, помимо id
+func NewQuery(params Params, sortFields ...SortField) (Query, error) {
+	if params.Limit == 0 {
+		params.Limit = DefaultLimit
+	}
+	if params.Limit < 1 || params.Limit > MaxLimit {
+		return Query{}, apperr.Invalid("limit", "must be between 1 and 100")
+	}
+	if params.SortBy == "" {
+		params.SortBy = SortByID
+	}
+	names := []string{SortByID}
+	for _, field := range sortFields {
+		names = append(names, field.Name)
+	}
+	i := slices.Index(names, params.SortBy)
+	if i < 0 {
+		return Query{}, apperr.Invalid("sort", "must be one of "+strings.Join(names, ", "))
+	}
+
+	query := Query{SortBy: params.SortBy, Desc: params.Desc, Limit: params.Limit + 1}
+	if i > 0 {
+		query.Numeric = sortFields[i-1].Numeric
+	}
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor)
+		if err != nil || c.SortBy != params.SortBy || c.Desc != params.Desc {
+			return Query{}, errInvalidCursor
+		}
+		query.After = &Position{ID: c.ID}
+		if c.Time != nil {
+			query.After.Time = *c.Time
+		}
+		if c.Number != nil {
+			query.After.Number = *c.Number
+		}
+		query.Backward = c.Backward
+	}
+	return query, nil
+}
+
+// NewPage собирает страницу из того, что вернул репозиторий на query:
+// отрезает лишнюю запись, возвращает обратный обход в нормальный порядок и строит курсоры
+func NewPage[T any](items []T, query Query, position func(T) Position) Page[T] {
+	size := query.Limit - 1
+	hasMore := len(items) > size
+	if hasMore {
+		items = items[:size]
+	}
+	if query.Backward {
+		slices.Reverse(items)
+	}
+
+	page := Page[T]{Items: items}
+	if len(items) == 0 {
+		return page
+	}
+	// Вперёд есть куда идти, если мы шли вперёд и нашлась лишняя запись
+	// или если мы пришли сюда назад с какой-то страницы. С prev наоборот
+	if (!query.Backward && hasMore) || (query.Backward && query.After != nil) {
+		page.NextCursor = encodeCursor(query, position(items[len(items)-1]), false)
+	}
+	if (query.Backward && hasMore) || (!query.Backward && query.After != nil) {
+		page.PrevCursor = encodeCursor(query, position(items[0]), true)
+	}
+	return page
+}
+
+// Apply добавляет к запросу gorm условие на позицию, порядок и лимит.
+// Позиция сравнивается как пара (поле, id), так что записи с одинаковым временем
+// не теряются и не повторяются на границе страниц. Имя колонки берётся из SortBy,
+// а его NewQuery пропускает только из разрешённого списка
+func Apply(tx *gorm.DB, query Query) *gorm.DB {
+	op, direction := ">", "ASC"
+	if !query.ascending() {
+		op, direction = "<", "DESC"
+	}
+
+	column := query.SortBy
+	if query.After != nil {
+		switch {
+		case column == SortByID:
+			tx = tx.Where("id "+op+" ?", query.After.ID)
+		case query.Numeric:
+			tx = tx.Where("("+column+", id) "+op+" (?, ?)", query.After.Number, query.After.ID)
+		default:
+			tx = tx.Where("("+column+", id) "+op+" (?, ?)", query.After.Time.UTC(), query.After.ID)
+		}
+	}
+	if column != SortByID {
+		tx = tx.Order(column + " " + direction)
+	}
+	return tx.Order("id " + direction).Limit(query.Limit)
+}
+
+// Slice делает то же, что Apply, для записей в памяти: сортирует, отбрасывает всё
+// до позиции и обрезает по лимиту. items при этом переупорядочивается
+func Slice[T any](items []T, query Query, position func(T) Position) []T {
+	ascending := query.ascending()
+	slices.SortFunc(items, func(a, b T) int {
+		c := compare(position(a), position(b))
+		if !ascending {
+			c = -c
+		}
+		return c
+	})
+	if query.After != nil {
+		items = slices.DeleteFunc(items, func(item T) bool {
+			c := compare(position(item), *query.After)
+			return (ascending && c <= 0) || (!ascending && c >= 0)
+		})
+	}
+	if len(items) > query.Limit {
+		items = items[:query.Limit]
+	}
+	return items
+}
+
+// ascending - идём ли по возрастанию: сортировка по возрастанию и идём вперёд, или наоборот
+func (q Query) ascending() bool {
+	return q.Desc == q.Backward
+}
+
+func compare(a, b Position) int {
+	if c := a.Time.Compare(b.Time); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Number, b.Number); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func encodeCursor(query Query, pos Position, backward bool) string {
+	c := cursor{
+		SortBy:   query.SortBy,
+		Desc:     query.Desc,
+		ID:       pos.ID,
+		Backward: backward,
+	}
+	switch {
+	case query.SortBy == SortByID:
+	case query.Numeric:
+		c.Number = &pos.Number
+	default:
+		c.Time = &pos.Time
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor{}, err
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return cursor{}, err
+	}
+	return c, nil
+}
+
+// EscapeLike экранирует % и _ в строке поиска для LIKE ... ESCAPE '\'
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,171 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithm - алгоритм, которым хешируются новые пароли
+type Algorithm string
+
+const (
+	Bcrypt   Algorithm = "bcrypt"
+	Argon2id Algorithm = "argon2id"
+)
+
+var (
+	// ErrMismatch возвращается, если пароль не совпадает с хешем
+	ErrMismatch = errors.New("password mismatch")
+	// ErrUnknownFormat возвращается, если хеш не похож ни на bcrypt, ни на argon2id
+	ErrUnknownFormat = errors.New("unknown password hash format")
+	// ErrTooLong возвращается, если bcrypt не может захешировать пароль такой длины
+	ErrTooLong = errors.New("password is too long")
+)
+
+// Config - параметры хеширования. Меняя их, мы не ломаем старые хеши:
+// они продолжают проверяться и пересчитываются при следующем входе
+type Config struct {
+	Algorithm  Algorithm
+	BcryptCost int
+
+	Argon2Memory      uint32 // в KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+}
+
+// DefaultConfig возвращает параметры по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Algorithm:         Bcrypt,
+		BcryptCost:        12,
+		Argon2Memory:      64 * 1024,
+		Argon2Iterations:  3,
+		Argon2Parallelism: 2,
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+	}
+}
+
+// Hasher хеширует и проверяет пароли
+type Hasher struct {
+	cfg Config
+}
+
+func NewHasher(cfg Config) (*Hasher, error) {
+	switch cfg.Algorithm {
+	case Bcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case Argon2id:
+		if cfg.Argon2Memory == 0 || cfg.Argon2Iterations == 0 || cfg.Argon2Parallelism == 0 ||
+			cfg.Argon2SaltLength == 0 || cfg.Argon2KeyLength == 0 {
+			return nil, errors.New("argon2id parameters must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password algorithm %q", cfg.Algorithm)
+	}
+	return &Hasher{cfg: cfg}, nil
+}
+
+// Hash хеширует пароль текущим алгоритмом
+func (h *Hasher) Hash(plain string) (string, error) {
+	if h.cfg.Algorithm == Argon2id {
+		return h.hashArgon2id(plain)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), h.cfg.BcryptCost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", ErrTooLong
+		}
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify проверяет пароль. needsRehash = true, если хеш сделан другим
+// алгоритмом или с другими параметрами и его стоит пересчитать
+func (h *Hasher) Verify(hash, plain string) (needsRehash bool, err error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		other := argon2.IDKey([]byte(plain), salt, params.Argon2Iterations, params.Argon2Memory, params.Argon2Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(key, other) != 1 {
+			return false, ErrMismatch
+		}
+		return h.cfg.Algorithm != Argon2id ||
+			params.Argon2Memory != h.cfg.Argon2Memory ||
+			params.Argon2Iterations != h.cfg.Argon2Iterations ||
+			params.Argon2Parallelism != h.cfg.Argon2Parallelism ||
+			uint32(len(salt)) != h.cfg.Argon2SaltLength ||
+			uint32(len(key)) != h.cfg.Argon2KeyLength, nil
+
+	case strings.HasPrefix(hash, "$2"):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, ErrMismatch
+			}
+			return false, err
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return false, err
+		}
+		return h.cfg.Algorithm != Bcrypt || cost != h.cfg.BcryptCost, nil
+	}
+	return false, ErrUnknownFormat
+}
+
+func (h *Hasher) hashArgon2id(plain string) (string, error) {
+	salt := make([]byte, h.cfg.Argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(plain), salt, h.cfg.Argon2Iterations, h.cfg.Argon2Memory, h.cfg.Argon2Parallelism, h.cfg.Argon2KeyLength)
+
+	// Формат PHC: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.cfg.Argon2Memory, h.cfg.Argon2Iterations, h.cfg.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func decodeArgon2id(hash string) (Config, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return Config{}, nil, nil, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Config{}, nil, nil, ErrUnknownFormat
+	}
+
+	var params Config
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Argon2Memory, &params.Argon2Iterations, &params.Argon2Parallelism); err != nil {
+		return Config{}, nil, nil, ErrUnknownFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Config{}, nil, nil, ErrUnknownFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Config{}, nil, nil, ErrUnknownFormat
+	}
+	return params, salt, key, nil
+}
@@ -0,0 +1,75 @@
+package policy
+
+import "pet1/internal/identity"
+
+// Action - действие над ресурсом
+type Action string
+
+const (
+	ReadTasks   Action = "tasks:read"
+	WriteTasks  Action = "tasks:write"
+	ReadUsers   Action = "users:read"
+	WriteUsers  Action = "users:write"
+	CreateUsers Action = "users:create"
+	ManageRoles Action = "users:manage_roles"
+	// ManageSettings - настройки работающего сервера, например уровень логирования
+	ManageSettings Action = "settings:manage"
+)
+
+// Scope - на чьи ресурсы распространяется разрешение
+type Scope int
+
+const (
+	// Own - только на свои: задачи вызывающего или его собственный профиль
+	Own Scope = iota + 1
+	// Any - на любые
+	Any
+)
+
+// rules - вся политика доступа в одном месте.
+// Для каждой роли перечислено, какие действия ей разрешены и в каком объёме
+var rules = map[identity.Role]map[Action]Scope{
+	identity.RoleAdmin: {
+		ReadTasks:      Any,
+		WriteTasks:     Any,
+		ReadUsers:      Any,
+		WriteUsers:     Any,
+		CreateUsers:    Any,
+		ManageRoles:    Any,
+		ManageSettings: Any,
+	},
+	identity.RoleMember: {
+		ReadTasks:  Own,
+		WriteTasks: Own,
+		ReadUsers:  Own,
+		WriteUsers: Own,
+	},
+	// Аудиторы видят всё, но ничего не меняют
+	identity.RoleReadOnly: {
+		ReadTasks: Any,
+		ReadUsers: Any,
+	},
+}
+
+// Can сообщает, может ли вызывающий выполнить действие над ресурсом пользователя ownerID
+func Can(caller identity.Caller, action Action, ownerID uint) bool {
+	switch rules[caller.Role][action] {
+	case Any:
+		return true
+	case Own:
+		return ownerID == caller.UserID
+	}
+	return false
+}
+
+// CanAny сообщает, может ли вызывающий выполнить действие над ресурсами любого пользователя
+func CanAny(caller identity.Caller, action Action) bool {
+	return rules[caller.Role][action] == Any
+}
+
+// Allowed сообщает, разрешено ли действие хотя бы над чем-то.
+// Удобно, чтобы сразу отказать роли, которой действие недоступно в принципе
+func Allowed(caller identity.Caller, action Action) bool {
+	_, ok := rules[caller.Role][action]
+	return ok
+}
@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"testing"
+
+	"pet1/internal/identity"
+)
+
+var actions = []Action{ReadTasks, WriteTasks, ReadUsers, WriteUsers, CreateUsers, ManageRoles, ManageSettings}
+
+// TestCan сверяет каждую роль с каждым действием: над своими ресурсами, над чужими
+// и над любыми сразу. Политика расписана здесь заново, независимо от rules
+func TestCan(t *testing.T) {
+	const self, other uint = 1, 2
+
+	type want struct{ own, others bool }
+	cases := map[identity.Role]map[Action]want{
+		identity.RoleAdmin: {
+			ReadTasks:      {true, true},
+			WriteTasks:     {true, true},
+			ReadUsers:      {true, true},
+			WriteUsers:     {true, true},
+			CreateUsers:    {true, true},
+			ManageRoles:    {true, true},
+			ManageSettings: {true, true},
+		},
+		// Участник работает только со своими задачами и своим профилем
+		identity.RoleMember: {
+			ReadTasks:  {true, false},
+			WriteTasks: {true, false},
+			ReadUsers:  {true, false},
+			WriteUsers: {true, false},
+		},
+		// Аудитор читает всё и не пишет ничего, даже своё
+		identity.RoleReadOnly: {
+			ReadTasks: {true, true},
+			ReadUsers: {true, true},
+		},
+		// Неизвестной роли не разрешено ничего
+		identity.Role("guest"): {},
+	}
+
+	for role, rules := range cases {
+		caller := identity.Caller{UserID: self, Role: role}
+		for _, action := range actions {
+			w, allowed := rules[action]
+			if got := Can(caller, action, self); got != w.own {
+				t.Errorf("%s: Can(%s, own) = %v, want %v", role, action, got, w.own)
+			}
+			if got := Can(caller, action, other); got != w.others {
+				t.Errorf("%s: Can(%s, other user's) = %v, want %v", role, action, got, w.others)
+			}
+			if got := CanAny(caller, action); got != w.others {
+				t.Errorf("%s: CanAny(%s) = %v, want %v", role, action, got, w.others)
+			}
+			if got := Allowed(caller, action); got != allowed {
+				t.Errorf("%s: Allowed(%s) = %v, want %v", role, action, got, allowed)
+			}
+		}
+	}
+}
+
+func TestReadOnlyNeverWrites(t *testing.T) {
+	caller := identity.Caller{UserID: 1, Role: identity.RoleReadOnly}
+	for _, action := range []Action{WriteTasks, WriteUsers, CreateUsers, ManageRoles, ManageSettings} {
+		for _, owner := range []uint{caller.UserID, 2} {
+			if Can(caller, action, owner) {
+				t.Errorf("read-only can %s for user %d", action, owner)
+			}
+		}
+	}
+}
+
+func TestMemberCannotTouchOthers(t *testing.T) {
+	caller := identity.Caller{UserID: 1, Role: identity.RoleMember}
+	for _, action := range []Action{ReadTasks, WriteTasks, ReadUsers, WriteUsers} {
+		if Can(caller, action, 2) {
+			t.Errorf("member can %s for another user", action)
+		}
+		if CanAny(caller, action) {
+			t.Errorf("member can %s for any user", action)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package reminder - фоновая рассылка напоминаний о задачах. Планировщик периодически
+// забирает задачи, у которых наступил remind_at, отправляет по каждой событие
+// и отмечает напоминание отправленным
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pet1/internal/taskService"
+	"pet1/internal/transaction"
+
+	"go.opentelemetry.io/otel"
+)
+
+var tracer = otel.Tracer("pet1/internal/reminder")
+
+// Event - напоминание о задаче
+type Event struct {
+	TaskID   uint
+	UserID   uint
+	Task     string
+	DueAt    *time.Time
+	RemindAt time.Time
+}
+
+// Notifier доставляет напоминания. Неудачное напоминание уйдёт ещё раз, когда
+// истечёт claimTTL, так что доставка «хотя бы раз», а не «ровно раз»
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Source - то, что планировщику нужно от хранилища задач
+type Source interface {
+	DueReminders(ctx context.Context, now time.Time, limit int) ([]taskService.Task, error)
+	ClaimReminders(ctx context.Context, ids []uint, until time.Time) error
+	MarkReminded(ctx context.Context, ids []uint, at time.Time) error
+}
+
+// claimTTL - на сколько планировщик забирает напоминания перед отправкой. Если
+// отправить не вышло или процесс упал, по истечении срока их заберёт другой проход
+const claimTTL = 5 * time.Minute
+
+// maxAttempts - после стольких неудачных попыток напоминание отмечается отправленным
+// без доставки: одно сломанное напоминание не должно повторяться вечно
+const maxAttempts = 5
+
+// Metrics - счётчик отправленных напоминаний
+type Metrics interface {
+	RemindersSent(n int)
+}
+
+// LogNotifier пишет напоминания в лог. Пока другой доставки нет, оттуда их забирает
+// тот, кому они нужны
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, event Event) error {
+	attrs := []any{"task_id", event.TaskID, "user_id", event.UserID, "task", event.Task, "remind_at", event.RemindAt}
+	if event.DueAt != nil {
+		attrs = append(attrs, "due_at", *event.DueAt)
+	}
+	slog.InfoContext(ctx, "task reminder", attrs...)
+	return nil
+}
+
+type Scheduler struct {
+	tasks     Source
+	tx        transaction.Manager
+	notifier  Notifier
+	metrics   Metrics
+	interval  time.Duration
+	batchSize int
+}
+
+func NewScheduler(tasks Source, tx transaction.Manager, notifier Notifier, metrics Metrics, interval time.Duration, batchSize int) *Scheduler {
+	return &Scheduler{
+		tasks:     tasks,
+		tx:        tx,
+		notifier:  notifier,
+		metrics:   metrics,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run проверяет напоминания сразу и затем раз в interval, пока не отменят ctx.
+// Ошибки прохода только пишутся в лог: следующий проход попробует снова
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Tick(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to send reminders", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick отправляет все напоминания, наступившие к now, пачками по batchSize.
+// Пачку забирает короткая транзакция, отправка идёт уже после коммита: её побочные
+// эффекты не откатить, и повтор транзакции не должен слать события второй раз.
+// Ошибка доставки не останавливает остальных: напоминание остаётся забранным
+// до истечения claimTTL и тогда уходит снова. Возвращает, сколько отправлено
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "ReminderScheduler.Tick")
+	defer span.End()
+
+	sent := 0
+	for {
+		var tasks []taskService.Task
+		err := s.tx.Do(ctx, func(ctx context.Context) error {
+			var err error
+			if tasks, err = s.tasks.DueReminders(ctx, now, s.batchSize); err != nil {
+				return fmt.Errorf("failed to get due reminders: %w", err)
+			}
+			ids := make([]uint, 0, len(tasks))
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			if err := s.tasks.ClaimReminders(ctx, ids, now.Add(claimTTL)); err != nil {
+				return fmt.Errorf("failed to claim reminders: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return sent, err
+		}
+
+		// Отправленные и брошенные больше не нужны, остальные ждут конца claimTTL
+		done := make([]uint, 0, len(tasks))
+		delivered := 0
+		for _, task := range tasks {
+			event := Event{
+				TaskID:   task.ID,
+				UserID:   task.UserID,
+				Task:     task.Task,
+				DueAt:    task.DueAt,
+				RemindAt: *task.RemindAt,
+			}
+			err := s.notifier.Notify(ctx, event)
+			switch {
+			case err == nil:
+				delivered++
+				done = append(done, task.ID)
+			// RemindAttempts прочитан до ClaimReminders, эта попытка в нём ещё не учтена
+			case task.RemindAttempts+1 >= maxAttempts:
+				slog.ErrorContext(ctx, "giving up on task reminder", "task_id", task.ID, "attempts", maxAttempts, "error", err)
+				done = append(done, task.ID)
+			default:
+				slog.WarnContext(ctx, "failed to send task reminder, will retry", "task_id", task.ID, "attempt", task.RemindAttempts+1, "error", err)
+			}
+		}
+
+		// Не отметились - напоминания уйдут ещё раз после claimTTL
+		if err := s.tasks.MarkReminded(ctx, done, now); err != nil {
+			return sent, fmt.Errorf("failed to mark reminders as sent: %w", err)
+		}
+		sent += delivered
+		s.metrics.RemindersSent(delivered)
+		if len(tasks) < s.batchSize {
+			return sent, nil
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"pet1/internal/taskService"
+	"pet1/internal/transaction"
+)
+
+var errUnreachable = errors.New("unreachable")
+
+// fakeNotifier запоминает доставленные напоминания и не доставляет по задачам из failing
+type fakeNotifier struct {
+	mu        sync.Mutex
+	failing   map[uint]bool
+	delivered []uint
+	attempts  map[uint]int
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, event Event) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.attempts == nil {
+		n.attempts = map[uint]int{}
+	}
+	n.attempts[event.TaskID]++
+	if n.failing[event.TaskID] {
+		return errUnreachable
+	}
+	n.delivered = append(n.delivered, event.TaskID)
+	return nil
+}
+
+type fakeMetrics struct{ sent int }
+
+func (m *fakeMetrics) RemindersSent(n int) { m.sent += n }
+
+// retryOnce - менеджер, у которого первая транзакция отменяется уже после fn,
+// как при конфликте сериализации на коммите, и выполняется ещё раз
+type retryOnce struct {
+	transaction.Manager
+	retried bool
+}
+
+func (m *retryOnce) Do(ctx context.Context, fn func(ctx context.Context) error, opts ...transaction.Option) error {
+	if !m.retried {
+		m.retried = true
+		err := m.Manager.Do(ctx, func(ctx context.Context) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return errors.New("could not serialize access")
+		}, opts...)
+		if err == nil {
+			panic("conflict was not rolled back")
+		}
+	}
+	return m.Manager.Do(ctx, fn, opts...)
+}
+
+var start = time.Date(2025, 2, 11, 9, 0, 0, 0, time.UTC)
+
+// seed заводит count задач, напоминания по которым наступили к start, в порядке remind_at
+func seed(t *testing.T, count int) ([]uint, Source, transaction.Manager) {
+	t.Helper()
+	repo := taskService.NewMemoryTaskRepository()
+	repo.SetUserLookup(func(id uint) bool { return id == 1 })
+	ids := []uint{}
+	for i := range count {
+		remindAt := start.Add(-time.Duration(count-i) * time.Minute)
+		task, err := repo.CreateTask(context.Background(), taskService.Task{Task: "call back", UserID: 1, RemindAt: &remindAt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, task.ID)
+	}
+	return ids, repo, transaction.NewMemoryManager(repo)
+}
+
+func TestTickSendsBatches(t *testing.T) {
+	ids, source, tx := seed(t, 5)
+	notifier, metrics := &fakeNotifier{}, &fakeMetrics{}
+	// Пачки по 2: все пять напоминаний уйдут за один Tick в трёх транзакциях
+	scheduler := NewScheduler(source, tx, notifier, metrics, time.Minute, 2)
+
+	sent, err := scheduler.Tick(context.Background(), start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 5 || metrics.sent != 5 || !slices.Equal(notifier.delivered, ids) {
+		t.Errorf("sent %d (metrics %d), delivered %v, want all of %v in remind_at order", sent, metrics.sent, notifier.delivered, ids)
+	}
+
+	// Отправленные отмечены и второй раз не уходят
+	if sent, err := scheduler.Tick(context.Background(), start.Add(claimTTL)); err != nil || sent != 0 {
+		t.Errorf("second Tick = %d, %v, want nothing to send", sent, err)
+	}
+}
+
+func TestTickFailingReminderDoesNotBlockOthers(t *testing.T) {
+	ids, source, tx := seed(t, 3)
+	failing := ids[0]
+	// Первое по remind_at напоминание не доставляется никогда
+	notifier := &fakeNotifier{failing: map[uint]bool{failing: true}}
+	scheduler := NewScheduler(source, tx, notifier, &fakeMetrics{}, time.Minute, 1)
+
+	sent, err := scheduler.Tick(context.Background(), start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 || !slices.Equal(notifier.delivered, ids[1:]) {
+		t.Fatalf("sent %d, delivered %v, want %v", sent, notifier.delivered, ids[1:])
+	}
+
+	// Пока напоминание забрано, его не трогают, потом пробуют снова - до maxAttempts раз
+	if _, err := scheduler.Tick(context.Background(), start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if got := notifier.attempts[failing]; got != 1 {
+		t.Fatalf("failing reminder tried %d times before claimTTL, want 1", got)
+	}
+	now := start
+	for range maxAttempts + 2 {
+		now = now.Add(claimTTL)
+		if _, err := scheduler.Tick(context.Background(), now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := notifier.attempts[failing]; got != maxAttempts {
+		t.Errorf("failing reminder tried %d times, want %d", got, maxAttempts)
+	}
+	due, err := source.DueReminders(context.Background(), now.Add(claimTTL), 10)
+	if err != nil || len(due) != 0 {
+		t.Errorf("due reminders after giving up = %v, %v, want none", due, err)
+	}
+}
+
+func TestTickNoDuplicateOnRetry(t *testing.T) {
+	ids, source, tx := seed(t, 3)
+	notifier := &fakeNotifier{}
+	scheduler := NewScheduler(source, &retryOnce{Manager: tx}, notifier, &fakeMetrics{}, time.Minute, 10)
+
+	sent, err := scheduler.Tick(context.Background(), start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 3 || !slices.Equal(notifier.delivered, ids) {
+		t.Errorf("sent %d, delivered %v, want each of %v once", sent, notifier.delivered, ids)
+	}
+}
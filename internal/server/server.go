@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"pet1/internal/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Server - HTTP-сервер с корректной остановкой: перестаём принимать новые
+// соединения и даём уже начатым запросам доработать до дедлайна
+type Server struct {
+	echo            *echo.Echo
+	addr            string
+	shutdownTimeout time.Duration
+	shutdownDelay   time.Duration
+	// draining выставляется, как только началась остановка
+	draining atomic.Bool
+}
+
+func New(e *echo.Echo, cfg config.HTTPConfig) *Server {
+	// О старте сообщаем сами через slog, баннер echo в JSON-логах не нужен
+	e.HideBanner = true
+	e.HidePort = true
+
+	// Таймауты сервера, чтобы медленные клиенты не держали соединения вечно
+	e.Server.ReadTimeout = cfg.ReadTimeout
+	e.Server.ReadHeaderTimeout = cfg.ReadHeaderTimeout
+	e.Server.WriteTimeout = cfg.WriteTimeout
+	e.Server.IdleTimeout = cfg.IdleTimeout
+
+	return &Server{
+		echo:            e,
+		addr:            cfg.Addr,
+		shutdownTimeout: cfg.ShutdownTimeout,
+		shutdownDelay:   cfg.ShutdownDelay,
+	}
+}
+
+// Run запускает сервер и блокируется, пока не отменят ctx (обычно по SIGTERM)
+// или сервер не упадёт сам. После отмены ctx сразу помечает себя как
+// останавливающийся (на это смотрит /readyz), выжидает shutdownDelay, пока
+// балансировщик уберёт под из ротации, и ждёт завершения начатых запросов,
+// но не дольше shutdownTimeout
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("http server started", "addr", s.addr)
+		errCh <- s.echo.Start(s.addr)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	s.draining.Store(true)
+	if s.shutdownDelay > 0 {
+		slog.Info("shutdown requested, keep serving until load balancers notice", "delay", s.shutdownDelay)
+		time.Sleep(s.shutdownDelay)
+	}
+
+	slog.Info("shutting down, waiting for in-flight requests", "timeout", s.shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := s.echo.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
+	}
+
+	// Start возвращает http.ErrServerClosed после Shutdown, это штатно
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Draining сообщает, что сервер уже останавливается
+func (s *Server) Draining() bool {
+	return s.draining.Load()
+}
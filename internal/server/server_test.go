@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"pet1/internal/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// startBlocking поднимает сервер на свободном порту с обработчиком /slow, который
+// сообщает о начале запроса в started и ждёт release. Возвращает адрес сервера
+// и канал с результатом Run
+func startBlocking(t *testing.T, ctx context.Context, cfg config.HTTPConfig, started chan<- struct{}, release <-chan struct{}) (*Server, string, <-chan error) {
+	t.Helper()
+
+	e := echo.New()
+	e.GET("/slow", func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.String(http.StatusOK, "done")
+	})
+
+	cfg.Addr = "127.0.0.1:0"
+	srv := New(e, cfg)
+	runErr := make(chan error, 1)
+	go func() { runErr <- srv.Run(ctx) }()
+
+	// Порт выбирает система, ждём, пока echo начнёт слушать
+	deadline := time.Now().Add(5 * time.Second)
+	for e.ListenerAddr() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("server did not start")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return srv, "http://" + e.ListenerAddr().String(), runErr
+}
+
+func TestRunDrainsInFlightRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	srv, url, runErr := startBlocking(t, ctx, config.HTTPConfig{ShutdownTimeout: 5 * time.Second}, started, release)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	response := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			response <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		response <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	<-started
+	if srv.Draining() {
+		t.Fatal("Draining() = true before shutdown")
+	}
+
+	// Как SIGTERM: запрос ещё выполняется, а сервер уже останавливается
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for !srv.Draining() {
+		if time.Now().After(deadline) {
+			t.Fatal("Draining() did not flip after ctx was cancelled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	select {
+	case err := <-runErr:
+		t.Fatalf("Run returned %v while a request was still in flight", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case r := <-response:
+		if r.err != nil {
+			t.Fatalf("in-flight request failed: %v", r.err)
+		}
+		if r.status != http.StatusOK || r.body != "done" {
+			t.Fatalf("in-flight request got %d %q, want 200 \"done\"", r.status, r.body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight request did not complete")
+	}
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			t.Fatalf("Run returned %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the last request finished")
+	}
+
+	// Новые соединения после остановки уже не принимаются
+	if resp, err := http.Get(url + "/slow"); err == nil {
+		resp.Body.Close()
+		t.Fatal("server accepted a request after shutdown")
+	}
+}
+
+func TestRunShutdownTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	_, url, runErr := startBlocking(t, ctx, config.HTTPConfig{ShutdownTimeout: 50 * time.Millisecond}, started, release)
+
+	go func() {
+		if resp, err := http.Get(url + "/slow"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+
+	// Запрос не успевает за shutdownTimeout - Run сообщает, что остановка не удалась
+	select {
+	case err := <-runErr:
+		if err == nil {
+			t.Fatal("Run returned nil, want shutdown timeout error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after shutdown timeout")
+	}
+}
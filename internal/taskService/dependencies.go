@@ -0,0 +1,292 @@
+package taskService
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"pet1/internal/apperr"
+)
+
+// Plan - в каком порядке делать несделанные задачи пользователя
+type Plan struct {
+	// Order - задачи так, что блокирующие идут раньше блокируемых. Из готовых
+	// к работе первой берётся более срочная, при равном приоритете - более старая
+	Order []Task
+	// CriticalPath - самая длинная по сумме оценок цепочка зависимых задач, от первой
+	// к последней. nil, если ни одна задача не оценена
+	CriticalPath []Task
+	// EstimateMinutes - сумма оценок задач критического пути
+	EstimateMinutes int
+}
+
+// GetBlockers возвращает задачи, которые блокируют задачу id, сделанные тоже
+func (s *TaskService) GetBlockers(ctx context.Context, id uint) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetBlockers")
+	defer span.End()
+
+	if _, err := s.getVisibleTask(ctx, id); err != nil {
+		return nil, err
+	}
+	blockers, err := s.repo.GetBlockers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.markBlocked(ctx, blockers); err != nil {
+		return nil, err
+	}
+	return blockers, nil
+}
+
+// AddBlocker отмечает, что задача blockerID блокирует задачу id. Обе задачи должны
+// быть одного владельца, а зависимости - оставаться без циклов: связь, которая
+// замкнула бы цикл, отклоняется, и в ошибке видно весь цикл
+func (s *TaskService) AddBlocker(ctx context.Context, id, blockerID uint) error {
+	ctx, span := tracer.Start(ctx, "TaskService.AddBlocker")
+	defer span.End()
+
+	// Две связи, добавленные одновременно, могут замкнуть цикл, которого ни одна
+	// транзакция не видит. Поэтому транзакция начинается с блокировки задач владельца,
+	// а владельца, который у задачи не меняется, узнаём до неё
+	owned, err := s.getOwnTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockUserTasks(ctx, owned.UserID); err != nil {
+			return err
+		}
+		task, err := s.getOwnTask(ctx, id)
+		if err != nil {
+			return err
+		}
+		if blockerID == id {
+			return errDependencyCycle([]uint{id, id})
+		}
+		blocker, err := s.repo.GetTaskByID(ctx, blockerID)
+		if err != nil && !errors.Is(err, ErrTaskNotFound) {
+			return err
+		}
+		// Как и с родителем, связывать свою задачу с чужой нельзя никому
+		if err != nil || blocker.UserID != task.UserID {
+			return apperr.Invalid("blocker_id", fmt.Sprintf("task %d does not exist", blockerID))
+		}
+
+		// Новая связь blockerID -> id замкнёт цикл, если от id уже можно дойти до blockerID
+		dependencies, err := s.repo.GetDependencies(ctx, task.UserID)
+		if err != nil {
+			return err
+		}
+		if path := dependencyPath(dependencies, id, blockerID); path != nil {
+			return errDependencyCycle(append([]uint{blockerID}, path...))
+		}
+		return s.repo.AddDependency(ctx, blockerID, id)
+	})
+}
+
+// RemoveBlocker убирает зависимость задачи id от blockerID
+func (s *TaskService) RemoveBlocker(ctx context.Context, id, blockerID uint) error {
+	ctx, span := tracer.Start(ctx, "TaskService.RemoveBlocker")
+	defer span.End()
+
+	if _, err := s.getOwnTask(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.RemoveDependency(ctx, blockerID, id)
+}
+
+// PlanTasks строит план по несделанным задачам пользователя, по умолчанию - вызывающего.
+// Сделанные блокирующие задачи уже никого не держат, поэтому в плане их нет
+func (s *TaskService) PlanTasks(ctx context.Context, userID uint) (Plan, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.PlanTasks")
+	defer span.End()
+
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return Plan{}, err
+	}
+	if userID, err = s.readableUser(ctx, caller, userID); err != nil {
+		return Plan{}, err
+	}
+
+	tasks, err := s.repo.GetTasksByUserID(ctx, userID)
+	if err != nil {
+		return Plan{}, err
+	}
+	dependencies, err := s.repo.GetDependencies(ctx, userID)
+	if err != nil {
+		return Plan{}, err
+	}
+	tasks = slices.DeleteFunc(tasks, func(task Task) bool { return task.IsDone })
+	return buildPlan(tasks, dependencies), nil
+}
+
+// markBlocked проставляет Blocked задачам одним запросом на всю выборку
+func (s *TaskService) markBlocked(ctx context.Context, tasks []Task) error {
+	ids := make([]uint, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	blocked, err := s.repo.BlockedTaskIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range tasks {
+		tasks[i].Blocked = slices.Contains(blocked, tasks[i].ID)
+	}
+	return nil
+}
+
+// openBlockers - ID несделанных задач, которые блокируют задачу id
+func (s *TaskService) openBlockers(ctx context.Context, id uint) ([]uint, error) {
+	blockers, err := s.repo.GetBlockers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	open := []uint{}
+	for _, blocker := range blockers {
+		if !blocker.IsDone {
+			open = append(open, blocker.ID)
+		}
+	}
+	return open, nil
+}
+
+// dependencyPath ищет обходом в ширину цепочку зависимостей от from до to,
+// обе задачи включительно. Цепочки нет - nil
+func dependencyPath(dependencies []TaskDependency, from, to uint) []uint {
+	next := map[uint][]uint{}
+	for _, dependency := range dependencies {
+		next[dependency.BlockerID] = append(next[dependency.BlockerID], dependency.BlockedID)
+	}
+	previous := map[uint]uint{}
+	seen := map[uint]bool{from: true}
+	queue := []uint{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == to {
+			path := []uint{to}
+			for id != from {
+				id = previous[id]
+				path = append(path, id)
+			}
+			slices.Reverse(path)
+			return path
+		}
+		for _, blocked := range next[id] {
+			if !seen[blocked] {
+				seen[blocked] = true
+				previous[blocked] = id
+				queue = append(queue, blocked)
+			}
+		}
+	}
+	return nil
+}
+
+// buildPlan сортирует задачи топологически алгоритмом Кана и ищет критический путь.
+// Связи с задачами не из набора не учитываются. Если в данных всё же окажется цикл,
+// его задачи попадут в конец порядка по ID
+func buildPlan(tasks []Task, dependencies []TaskDependency) Plan {
+	byID := make(map[uint]Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	next := map[uint][]uint{}
+	blockers := map[uint]int{}
+	for _, dependency := range dependencies {
+		_, blockerOK := byID[dependency.BlockerID]
+		_, blockedOK := byID[dependency.BlockedID]
+		if blockerOK && blockedOK {
+			next[dependency.BlockerID] = append(next[dependency.BlockerID], dependency.BlockedID)
+			blockers[dependency.BlockedID]++
+		}
+	}
+
+	// ready держим отсортированным: первой идёт самая срочная, потом самая старая
+	byUrgency := func(a, b Task) int {
+		return cmp.Or(cmp.Compare(a.Priority, b.Priority), cmp.Compare(a.ID, b.ID))
+	}
+	ready := []Task{}
+	push := func(task Task) {
+		i, _ := slices.BinarySearchFunc(ready, task, byUrgency)
+		ready = slices.Insert(ready, i, task)
+	}
+	for _, task := range tasks {
+		task.Blocked = blockers[task.ID] > 0
+		byID[task.ID] = task
+		if !task.Blocked {
+			push(task)
+		}
+	}
+
+	plan := Plan{Order: make([]Task, 0, len(tasks))}
+	placed := map[uint]bool{}
+	for len(ready) > 0 {
+		task := ready[0]
+		ready = ready[1:]
+		plan.Order = append(plan.Order, task)
+		placed[task.ID] = true
+		for _, blocked := range next[task.ID] {
+			if blockers[blocked]--; blockers[blocked] == 0 {
+				push(byID[blocked])
+			}
+		}
+	}
+	for _, task := range tasks {
+		if !placed[task.ID] {
+			plan.Order = append(plan.Order, byID[task.ID])
+		}
+	}
+
+	plan.CriticalPath, plan.EstimateMinutes = criticalPath(plan.Order, next)
+	return plan
+}
+
+// criticalPath - самая длинная по сумме оценок цепочка в графе next. Задачи без
+// оценки считаются нулевыми. order - топологический порядок, в нём у каждой задачи
+// все блокирующие уже посчитаны к моменту, когда до неё доходит очередь
+func criticalPath(order []Task, next map[uint][]uint) ([]Task, int) {
+	if !slices.ContainsFunc(order, func(task Task) bool { return task.EstimateMinutes != nil }) {
+		return nil, 0
+	}
+	estimate := func(task Task) int {
+		if task.EstimateMinutes == nil {
+			return 0
+		}
+		return *task.EstimateMinutes
+	}
+
+	byID := make(map[uint]Task, len(order))
+	length := make(map[uint]int, len(order))
+	previous := map[uint]uint{}
+	for _, task := range order {
+		byID[task.ID] = task
+		length[task.ID] = estimate(task)
+	}
+	var end Task
+	for i, task := range order {
+		for _, blocked := range next[task.ID] {
+			// При равной длине цепочка через блокирующую задачу нагляднее, чем задача сама по себе
+			_, chained := previous[blocked]
+			if candidate := length[task.ID] + estimate(byID[blocked]); candidate > length[blocked] || candidate == length[blocked] && !chained {
+				length[blocked] = candidate
+				previous[blocked] = task.ID
+			}
+		}
+		if i == 0 || length[task.ID] > length[end.ID] {
+			end = task
+		}
+	}
+
+	// Длина пути ограничена на случай цикла в данных
+	path := []Task{end}
+	for id, ok := previous[end.ID]; ok && len(path) < len(order); id, ok = previous[id] {
+		path = append(path, byID[id])
+	}
+	slices.Reverse(path)
+	return path, length[end.ID]
+}
@@ -0,0 +1,89 @@
+package taskService
+
+import (
+	"time"
+
+	"pet1/internal/apperr"
+)
+
+// Быстрые фильтры по сроку задачи. Границы дня и недели считаются
+// в часовом поясе вызывающего
+const (
+	// DueOverdue - срок уже прошёл, а задача не сделана
+	DueOverdue = "overdue"
+	// DueToday - срок сегодня
+	DueToday = "today"
+	// DueWeek - срок на этой неделе, с понедельника по воскресенье
+	DueWeek = "week"
+)
+
+// applyDue переводит быстрый фильтр по сроку в границы TaskFilter на момент now
+func (p *ListParams) applyDue(now time.Time) error {
+	if p.Due == "" {
+		return nil
+	}
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	var after, before time.Time
+	switch p.Due {
+	case DueOverdue:
+		if p.IsDone != nil && *p.IsDone {
+			return apperr.Invalid("is_done", "overdue tasks are never done")
+		}
+		notDone := false
+		p.IsDone = &notDone
+		p.DueBefore = &now
+		return nil
+	case DueToday:
+		// AddDate, а не 24 часа: в день перехода на летнее время в сутках 23 или 25 часов
+		after, before = today, today.AddDate(0, 0, 1)
+	case DueWeek:
+		// Weekday считает неделю с воскресенья, а у нас она начинается с понедельника
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		after, before = monday, monday.AddDate(0, 0, 7)
+	default:
+		return apperr.Invalid("due", "must be one of overdue, today, week")
+	}
+	p.DueAfter, p.DueBefore = &after, &before
+	return nil
+}
+
+// applyDates переносит в task срок и напоминание из update. nil - поле не меняется,
+// нулевое время - поле очищается. Если напоминание перенесли, оно будет отправлено заново
+func applyDates(task *Task, update Task) {
+	if update.DueAt != nil {
+		task.DueAt = clearable(update.DueAt)
+	}
+	if update.RemindAt != nil {
+		remindAt := clearable(update.RemindAt)
+		// Новое время - новое напоминание, со своими попытками
+		if !sameTime(task.RemindAt, remindAt) {
+			task.RemindedAt = nil
+			task.RemindClaimedUntil = nil
+			task.RemindAttempts = 0
+		}
+		task.RemindAt = remindAt
+	}
+}
+
+// clearable - nil вместо нулевого времени, остальное в UTC, как created_at
+// и updated_at: SQLite сравнивает время строками, смещения там должны совпадать
+func clearable(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
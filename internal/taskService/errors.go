@@ -0,0 +1,49 @@
+package taskService
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"pet1/internal/apperr"
+)
+
+var (
+	// ErrTaskNotFound - задачи нет или она принадлежит другому пользователю
+	ErrTaskNotFound = apperr.NotFound("task not found")
+	// ErrUserNotFound - пользователя нет или его задачи вызывающему не видны
+	ErrUserNotFound = apperr.NotFound("user not found")
+	// ErrUnauthenticated - в контексте нет вызывающего
+	ErrUnauthenticated = apperr.Unauthenticated("unauthenticated")
+	// ErrForbidden - вызывающему не разрешено это действие
+	ErrForbidden = apperr.Forbidden("forbidden")
+	// ErrLabelNotFound - метки нет или она принадлежит другому пользователю
+	ErrLabelNotFound = apperr.NotFound("label not found")
+	// ErrLabelNameTaken - у пользователя уже есть метка с таким именем
+	ErrLabelNameTaken = apperr.Conflict("label name already taken")
+	// ErrTaskCycle - задачу пытаются положить под её же подзадачу или под неё саму
+	ErrTaskCycle = apperr.Conflict("task cannot be nested under itself or its subtask")
+	// ErrOpenSubtasks - задачу отмечают сделанной, а её подзадачи ещё не сделаны
+	ErrOpenSubtasks = apperr.Conflict("task has open subtasks")
+	// ErrDependencyNotFound - такой зависимости между задачами нет
+	ErrDependencyNotFound = apperr.NotFound("dependency not found")
+)
+
+// errDependencyCycle - новая зависимость замкнула бы цикл. cycle - задачи цикла
+// по порядку блокировки, первая в конце повторяется
+func errDependencyCycle(cycle []uint) error {
+	return apperr.Conflict("dependency would create a cycle: " + joinIDs(cycle, " -> "))
+}
+
+// errTaskBlocked - задачу закрывают, а блокирующие её задачи ещё не сделаны
+func errTaskBlocked(blockers []uint) error {
+	return apperr.Conflict(fmt.Sprintf("task is blocked by open tasks %s, use force to complete it anyway", joinIDs(blockers, ", ")))
+}
+
+func joinIDs(ids []uint, sep string) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatUint(uint64(id), 10))
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,158 @@
+package taskService
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryLabelRepository - LabelRepository в памяти процесса. Имена уникальны
+// в пределах пользователя, как в индексе idx_labels_user_id_name
+type memoryLabelRepository struct {
+	mu     sync.RWMutex
+	labels map[uint]Label
+	lastID uint
+}
+
+func NewMemoryLabelRepository() *memoryLabelRepository {
+	return &memoryLabelRepository{labels: map[uint]Label{}}
+}
+
+func (r *memoryLabelRepository) CreateLabel(ctx context.Context, label Label) (Label, error) {
+	if err := ctx.Err(); err != nil {
+		return Label{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(label) {
+		return Label{}, ErrLabelNameTaken
+	}
+	r.lastID++
+	now := time.Now()
+	label.ID = r.lastID
+	label.CreatedAt = now
+	label.UpdatedAt = now
+	r.labels[label.ID] = label
+	return label, nil
+}
+
+func (r *memoryLabelRepository) GetLabelByID(ctx context.Context, id uint) (Label, error) {
+	if err := ctx.Err(); err != nil {
+		return Label{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	label, ok := r.labels[id]
+	if !ok {
+		return Label{}, ErrLabelNotFound
+	}
+	return label, nil
+}
+
+func (r *memoryLabelRepository) GetLabelsByIDs(ctx context.Context, ids []uint) ([]Label, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.Lookup(ids), nil
+}
+
+func (r *memoryLabelRepository) ListLabels(ctx context.Context, userID uint) ([]Label, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	labels := []Label{}
+	for _, label := range r.labels {
+		if label.UserID == userID {
+			labels = append(labels, label)
+		}
+	}
+	sortLabels(labels)
+	return labels, nil
+}
+
+func (r *memoryLabelRepository) UpdateLabel(ctx context.Context, label Label) (Label, error) {
+	if err := ctx.Err(); err != nil {
+		return Label{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.labels[label.ID]; !ok {
+		return Label{}, ErrLabelNotFound
+	}
+	if r.nameTaken(label) {
+		return Label{}, ErrLabelNameTaken
+	}
+	label.UpdatedAt = time.Now()
+	r.labels[label.ID] = label
+	return label, nil
+}
+
+// DeleteLabel удаляет метку. Её ID в задачах остаются, но Lookup их больше не находит
+func (r *memoryLabelRepository) DeleteLabel(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.labels[id]; !ok {
+		return ErrLabelNotFound
+	}
+	delete(r.labels, id)
+	return nil
+}
+
+// Lookup возвращает существующие метки из ids по имени. Для SetLabelLookup у задач
+func (r *memoryLabelRepository) Lookup(ids []uint) []Label {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	labels := []Label{}
+	for _, id := range ids {
+		if label, ok := r.labels[id]; ok {
+			labels = append(labels, label)
+		}
+	}
+	sortLabels(labels)
+	return labels
+}
+
+// Snapshot запоминает метки для отката транзакции
+func (r *memoryLabelRepository) Snapshot() func() {
+	r.mu.RLock()
+	saved := maps.Clone(r.labels)
+	r.mu.RUnlock()
+	return func() {
+		r.mu.Lock()
+		r.labels = saved
+		r.mu.Unlock()
+	}
+}
+
+// nameTaken - есть ли у владельца другая метка с тем же именем. Вызывать под блокировкой
+func (r *memoryLabelRepository) nameTaken(label Label) bool {
+	for _, other := range r.labels {
+		if other.ID != label.ID && other.UserID == label.UserID && other.Name == label.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortLabels(labels []Label) {
+	slices.SortFunc(labels, func(a, b Label) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return int(a.ID) - int(b.ID)
+	})
+}
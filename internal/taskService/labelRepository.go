@@ -0,0 +1,91 @@
+package taskService
+
+import (
+	"context"
+	"errors"
+
+	"pet1/internal/transaction"
+
+	"gorm.io/gorm"
+)
+
+type labelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) *labelRepository {
+	return &labelRepository{db: db}
+}
+
+func (r *labelRepository) CreateLabel(ctx context.Context, label Label) (Label, error) {
+	ctx, span := tracer.Start(ctx, "LabelRepository.CreateLabel")
+	defer span.End()
+
+	if err := transaction.Conn(ctx, r.db).Create(&label).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return Label{}, ErrLabelNameTaken
+		}
+		return Label{}, err
+	}
+	return label, nil
+}
+
+func (r *labelRepository) GetLabelByID(ctx context.Context, id uint) (Label, error) {
+	ctx, span := tracer.Start(ctx, "LabelRepository.GetLabelByID")
+	defer span.End()
+
+	var label Label
+	if err := transaction.Conn(ctx, r.db).First(&label, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Label{}, ErrLabelNotFound
+		}
+		return Label{}, err
+	}
+	return label, nil
+}
+
+func (r *labelRepository) GetLabelsByIDs(ctx context.Context, ids []uint) ([]Label, error) {
+	ctx, span := tracer.Start(ctx, "LabelRepository.GetLabelsByIDs")
+	defer span.End()
+
+	labels := []Label{}
+	err := transaction.Conn(ctx, r.db).Where("id IN ?", ids).Order("name").Find(&labels).Error
+	return labels, err
+}
+
+func (r *labelRepository) ListLabels(ctx context.Context, userID uint) ([]Label, error) {
+	ctx, span := tracer.Start(ctx, "LabelRepository.ListLabels")
+	defer span.End()
+
+	labels := []Label{}
+	err := transaction.Conn(ctx, r.db).Where("user_id = ?", userID).Order("name").Find(&labels).Error
+	return labels, err
+}
+
+func (r *labelRepository) UpdateLabel(ctx context.Context, label Label) (Label, error) {
+	ctx, span := tracer.Start(ctx, "LabelRepository.UpdateLabel")
+	defer span.End()
+
+	if err := transaction.Conn(ctx, r.db).Save(&label).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return Label{}, ErrLabelNameTaken
+		}
+		return Label{}, err
+	}
+	return label, nil
+}
+
+// DeleteLabel удаляет метку. Связи с задачами удалит внешний ключ ON DELETE CASCADE
+func (r *labelRepository) DeleteLabel(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "LabelRepository.DeleteLabel")
+	defer span.End()
+
+	result := transaction.Conn(ctx, r.db).Delete(&Label{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLabelNotFound
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package taskService
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"pet1/internal/apperr"
+	"pet1/internal/policy"
+)
+
+// Приоритеты задач: чем меньше число, тем срочнее
+const (
+	PriorityHighest = 0
+	PriorityDefault = 2
+	PriorityLowest  = 3
+)
+
+const (
+	// maxLabelNameLength - ограничение колонки labels.name VARCHAR(50)
+	maxLabelNameLength = 50
+	// defaultLabelColor - цвет метки, если его не указали
+	defaultLabelColor = "#9e9e9e"
+)
+
+var labelColor = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// LabelRepository - хранилище меток
+type LabelRepository interface {
+	// CreateLabel - ErrLabelNameTaken, если у пользователя уже есть метка с таким именем
+	CreateLabel(ctx context.Context, label Label) (Label, error)
+	// GetLabelByID - метка по ID или ErrLabelNotFound
+	GetLabelByID(ctx context.Context, id uint) (Label, error)
+	// GetLabelsByIDs - метки с этими ID, каких нет - тех нет и в ответе
+	GetLabelsByIDs(ctx context.Context, ids []uint) ([]Label, error)
+	// ListLabels - все метки пользователя по имени
+	ListLabels(ctx context.Context, userID uint) ([]Label, error)
+	// UpdateLabel сохраняет имя и цвет метки. ErrLabelNameTaken, если имя занято
+	UpdateLabel(ctx context.Context, label Label) (Label, error)
+	// DeleteLabel удаляет метку вместе с её связями с задачами
+	DeleteLabel(ctx context.Context, id uint) error
+}
+
+// TaskUpdate - изменения задачи помимо полей Task. nil и пустые списки ничего не меняют
+type TaskUpdate struct {
+	// IsDone - новое состояние задачи. Без него задача остаётся сделанной или несделанной, как была
+	IsDone   *bool
+	Priority *int
+	// ParentID - новый родитель, 0 - сделать задачей верхнего уровня
+	ParentID     *uint
+	AttachLabels []uint
+	DetachLabels []uint
+	// EstimateMinutes - новая оценка, ClearEstimate - убрать оценку
+	EstimateMinutes *int
+	ClearEstimate   bool
+	// Force закрывает задачу, даже если блокирующие её задачи ещё не сделаны
+	Force bool
+}
+
+// CreateLabel создаёт метку вызывающему
+func (s *TaskService) CreateLabel(ctx context.Context, label Label) (Label, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.CreateLabel")
+	defer span.End()
+
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return Label{}, err
+	}
+	if !policy.Can(caller, policy.WriteTasks, caller.UserID) {
+		return Label{}, ErrForbidden
+	}
+
+	if label.Color == "" {
+		label.Color = defaultLabelColor
+	}
+	label, err = normalizeLabel(label)
+	if err != nil {
+		return Label{}, err
+	}
+	if label.Name == "" {
+		return Label{}, apperr.Invalid("name", "must not be empty")
+	}
+	label.UserID = caller.UserID
+	return s.labels.CreateLabel(ctx, label)
+}
+
+// ListLabels возвращает метки вызывающего
+func (s *TaskService) ListLabels(ctx context.Context) ([]Label, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ListLabels")
+	defer span.End()
+
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Can(caller, policy.ReadTasks, caller.UserID) {
+		return nil, ErrForbidden
+	}
+	return s.labels.ListLabels(ctx, caller.UserID)
+}
+
+// UpdateLabelByID меняет имя и цвет метки. Пустые поля не меняются
+func (s *TaskService) UpdateLabelByID(ctx context.Context, id uint, update Label) (Label, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.UpdateLabelByID")
+	defer span.End()
+
+	label, err := s.getOwnLabel(ctx, id)
+	if err != nil {
+		return Label{}, err
+	}
+	update, err = normalizeLabel(update)
+	if err != nil {
+		return Label{}, err
+	}
+	if update.Name != "" {
+		label.Name = update.Name
+	}
+	if update.Color != "" {
+		label.Color = update.Color
+	}
+	return s.labels.UpdateLabel(ctx, label)
+}
+
+// DeleteLabelByID удаляет метку. Задачи остаются, только без неё
+func (s *TaskService) DeleteLabelByID(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "TaskService.DeleteLabelByID")
+	defer span.End()
+
+	if _, err := s.getOwnLabel(ctx, id); err != nil {
+		return err
+	}
+	return s.labels.DeleteLabel(ctx, id)
+}
+
+// getOwnLabel возвращает метку вызывающего. Чужие метки для него не существуют
+func (s *TaskService) getOwnLabel(ctx context.Context, id uint) (Label, error) {
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return Label{}, err
+	}
+	label, err := s.labels.GetLabelByID(ctx, id)
+	if err != nil {
+		return Label{}, err
+	}
+	if label.UserID != caller.UserID || !policy.Can(caller, policy.WriteTasks, caller.UserID) {
+		return Label{}, ErrLabelNotFound
+	}
+	return label, nil
+}
+
+// labelsOf проверяет, что все метки ids есть у владельца задачи, и возвращает их.
+// Чужие метки вешать на задачу нельзя, даже тому, кто может писать в чужие задачи
+func (s *TaskService) labelsOf(ctx context.Context, ownerID uint, field string, ids []uint) ([]Label, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	labels, err := s.labels.GetLabelsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		i := slices.IndexFunc(labels, func(label Label) bool { return label.ID == id })
+		if i < 0 || labels[i].UserID != ownerID {
+			return nil, apperr.Invalid(field, fmt.Sprintf("label %d does not exist", id))
+		}
+	}
+	return labels, nil
+}
+
+// normalizeLabel обрезает пробелы в имени, приводит цвет к нижнему регистру и проверяет оба
+func normalizeLabel(label Label) (Label, error) {
+	label.Name = strings.TrimSpace(label.Name)
+	label.Color = strings.ToLower(label.Color)
+	if utf8.RuneCountInString(label.Name) > maxLabelNameLength {
+		return Label{}, apperr.Invalid("name", "must be at most 50 characters")
+	}
+	if label.Color != "" && !labelColor.MatchString(label.Color) {
+		return Label{}, apperr.Invalid("color", "must look like #rrggbb")
+	}
+	return label, nil
+}
+
+func validatePriority(priority int) error {
+	if priority < PriorityHighest || priority > PriorityLowest {
+		return apperr.Invalid("priority", "must be between 0 and 3")
+	}
+	return nil
+}
+
+func validateEstimate(estimate *int) error {
+	if estimate != nil && *estimate < 0 {
+		return apperr.Invalid("estimate_minutes", "must not be negative")
+	}
+	return nil
+}
+
+// labelIDs - ID меток задачи
+func labelIDs(labels []Label) []uint {
+	ids := make([]uint, 0, len(labels))
+	for _, label := range labels {
+		ids = append(ids, label.ID)
+	}
+	return ids
+}
@@ -0,0 +1,165 @@
+package taskService
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"pet1/internal/identity"
+	"pet1/internal/pagination"
+	"pet1/internal/policy"
+)
+
+// Поля, по которым можно сортировать список задач, помимо id
+const (
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+	SortByPriority  = "priority"
+)
+
+var sortFields = []pagination.SortField{
+	{Name: SortByCreatedAt},
+	{Name: SortByUpdatedAt},
+	{Name: SortByPriority, Numeric: true},
+}
+
+// TaskFilter - условия отбора задач. Нулевые значения означают «не фильтровать»,
+// кроме UserID: в запросе клиента ноль - задачи вызывающего
+type TaskFilter struct {
+	UserID        uint
+	IsDone        *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	// DueAfter и DueBefore оставляют только задачи со сроком в этих границах
+	DueAfter  *time.Time
+	DueBefore *time.Time
+	// Labels оставляет задачи хотя бы с одной из этих меток, а с AllLabels - со всеми сразу
+	Labels    []uint
+	AllLabels bool
+	// Blocked оставляет только заблокированные задачи или, если false, только незаблокированные
+	Blocked *bool
+}
+
+// ListParams - запрос страницы задач в том виде, в каком он пришёл от клиента
+type ListParams struct {
+	TaskFilter
+	pagination.Params
+	// Due - быстрый фильтр по сроку: DueOverdue, DueToday или DueWeek
+	Due string
+	// Location - часовой пояс вызывающего для Due, nil - UTC
+	Location *time.Location
+}
+
+// TaskQuery - запрос страницы к репозиторию
+type TaskQuery struct {
+	TaskFilter
+	pagination.Query
+}
+
+// ListTasks возвращает страницу задач пользователя, по умолчанию - вызывающего.
+// Чужой или несуществующий пользователь для того, кому его задачи не видны, - ErrUserNotFound
+func (s *TaskService) ListTasks(ctx context.Context, params ListParams) (pagination.Page[Task], error) {
+	ctx, span := tracer.Start(ctx, "TaskService.ListTasks")
+	defer span.End()
+
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return pagination.Page[Task]{}, err
+	}
+
+	query, err := pagination.NewQuery(params.Params, sortFields...)
+	if err != nil {
+		return pagination.Page[Task]{}, err
+	}
+
+	if err := params.applyDue(time.Now()); err != nil {
+		return pagination.Page[Task]{}, err
+	}
+
+	if params.UserID, err = s.readableUser(ctx, caller, params.UserID); err != nil {
+		return pagination.Page[Task]{}, err
+	}
+
+	tasks, err := s.repo.ListTasks(ctx, TaskQuery{TaskFilter: params.TaskFilter, Query: query})
+	if err != nil {
+		return pagination.Page[Task]{}, err
+	}
+	if err := s.markBlocked(ctx, tasks); err != nil {
+		return pagination.Page[Task]{}, err
+	}
+	page := pagination.NewPage(tasks, query, func(task Task) pagination.Position {
+		return positionOf(task, query.SortBy)
+	})
+
+	if params.Count {
+		total, err := s.repo.CountTasks(ctx, params.TaskFilter)
+		if err != nil {
+			return pagination.Page[Task]{}, err
+		}
+		page.Total = &total
+	}
+	return page, nil
+}
+
+// readableUser возвращает пользователя, чьи задачи читают, 0 - вызывающий. Чужой
+// или несуществующий пользователь для того, кому его задачи не видны, - ErrUserNotFound
+func (s *TaskService) readableUser(ctx context.Context, caller identity.Caller, userID uint) (uint, error) {
+	if userID == 0 {
+		userID = caller.UserID
+	}
+	if !policy.Can(caller, policy.ReadTasks, userID) {
+		return 0, ErrUserNotFound
+	}
+	if userID != caller.UserID {
+		// Пустой список для пользователя, которого нет, выглядел бы как «задач нет»
+		exists, err := s.users.UserExists(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		if !exists {
+			return 0, ErrUserNotFound
+		}
+	}
+	return userID, nil
+}
+
+// match проверяет задачу на соответствие фильтру. Нужен хранилищам в памяти,
+// база делает то же самое условиями WHERE
+func (f TaskFilter) match(task Task) bool {
+	return task.UserID == f.UserID &&
+		(f.IsDone == nil || task.IsDone == *f.IsDone) &&
+		(f.CreatedAfter == nil || !task.CreatedAt.Before(*f.CreatedAfter)) &&
+		(f.CreatedBefore == nil || task.CreatedAt.Before(*f.CreatedBefore)) &&
+		(f.UpdatedAfter == nil || !task.UpdatedAt.Before(*f.UpdatedAfter)) &&
+		(f.UpdatedBefore == nil || task.UpdatedAt.Before(*f.UpdatedBefore)) &&
+		(f.DueAfter == nil || (task.DueAt != nil && !task.DueAt.Before(*f.DueAfter))) &&
+		(f.DueBefore == nil || (task.DueAt != nil && task.DueAt.Before(*f.DueBefore))) &&
+		f.matchLabels(task) &&
+		(f.Blocked == nil || task.Blocked == *f.Blocked)
+}
+
+func (f TaskFilter) matchLabels(task Task) bool {
+	if len(f.Labels) == 0 {
+		return true
+	}
+	has := func(id uint) bool { return slices.Contains(labelIDs(task.Labels), id) }
+	if f.AllLabels {
+		return !slices.ContainsFunc(f.Labels, func(id uint) bool { return !has(id) })
+	}
+	return slices.ContainsFunc(f.Labels, has)
+}
+
+// positionOf возвращает место задачи в сортировке по полю sortBy
+func positionOf(task Task, sortBy string) pagination.Position {
+	switch sortBy {
+	case SortByCreatedAt:
+		return pagination.Position{Time: task.CreatedAt, ID: task.ID}
+	case SortByUpdatedAt:
+		return pagination.Position{Time: task.UpdatedAt, ID: task.ID}
+	case SortByPriority:
+		return pagination.Position{Number: int64(task.Priority), ID: task.ID}
+	}
+	return pagination.Position{ID: task.ID}
+}
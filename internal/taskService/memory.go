@@ -0,0 +1,508 @@
+package taskService
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"pet1/internal/apperr"
+	"pet1/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+// memoryTaskRepository - TaskRepository в памяти процесса. Ведёт себя как gorm-версия:
+// ID выдаются по возрастанию и не переиспользуются, удаление мягкое,
+// удалённые задачи не находятся. Нужен для тестов и запуска без базы
+type memoryTaskRepository struct {
+	mu     sync.RWMutex
+	tasks  map[uint]Task
+	lastID uint
+	// userExists заменяет внешний ключ tasks.user_id. nil - проверки нет
+	userExists func(id uint) bool
+	// labels находит метки по ID вместо JOIN с labels. В задачах хранятся только
+	// ID меток, так что переименованная метка сразу видна в задачах, а удалённая пропадает
+	labels func(ids []uint) []Label
+	// dependencies - связи «блокирующая - блокируемая», как строки task_dependencies
+	dependencies map[TaskDependency]bool
+}
+
+func NewMemoryTaskRepository() *memoryTaskRepository {
+	return &memoryTaskRepository{tasks: map[uint]Task{}, dependencies: map[TaskDependency]bool{}}
+}
+
+// SetUserLookup подключает проверку владельца, как это делает внешний ключ в базе.
+// Пользователи живут в своём репозитории, поэтому связываем их уже после создания обоих
+func (r *memoryTaskRepository) SetUserLookup(userExists func(id uint) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.userExists = userExists
+}
+
+// SetLabelLookup подключает метки. Без него задачи читаются без меток
+func (r *memoryTaskRepository) SetLabelLookup(labels func(ids []uint) []Label) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.labels = labels
+}
+
+func (r *memoryTaskRepository) CreateTask(ctx context.Context, task Task) (Task, error) {
+	if err := ctx.Err(); err != nil {
+		return Task{}, err
+	}
+	// Владельца проверяем до своей блокировки: репозиторий пользователей
+	// сам ходит сюда за задачами, и встречные блокировки привели бы к взаимной.
+	// Пользователи удаляются только мягко, так что проверка не устареет
+	r.mu.RLock()
+	userExists := r.userExists
+	r.mu.RUnlock()
+	if userExists != nil && !userExists(task.UserID) {
+		return Task{}, apperr.Invalid("user_id", "user does not exist")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	now := time.Now()
+	task.ID = r.lastID
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	task.DeletedAt = gorm.DeletedAt{}
+	stored := task
+	stored.Labels = labelRefs(labelIDs(task.Labels))
+	r.tasks[task.ID] = stored
+	return task, nil
+}
+
+func (r *memoryTaskRepository) GetAllTasks(ctx context.Context) ([]Task, error) {
+	return r.find(ctx, func(Task) bool { return true })
+}
+
+func (r *memoryTaskRepository) GetTaskByID(ctx context.Context, id uint) (Task, error) {
+	if err := ctx.Err(); err != nil {
+		return Task{}, err
+	}
+	r.mu.RLock()
+	task, ok := r.get(id)
+	r.mu.RUnlock()
+	if !ok {
+		return Task{}, ErrTaskNotFound
+	}
+	return r.withLabels([]Task{task})[0], nil
+}
+
+func (r *memoryTaskRepository) UpdateTaskByID(ctx context.Context, id uint, task Task) (Task, error) {
+	if err := ctx.Err(); err != nil {
+		return Task{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existingTask, ok := r.get(id)
+	if !ok {
+		return Task{}, ErrTaskNotFound
+	}
+	if task.Task != "" {
+		existingTask.Task = task.Task
+	}
+	existingTask.IsDone = task.IsDone
+	existingTask.Priority = task.Priority
+	existingTask.ParentID = task.ParentID
+	existingTask.EstimateMinutes = task.EstimateMinutes
+	applyDates(&existingTask, task)
+	existingTask.UpdatedAt = time.Now()
+	r.tasks[id] = existingTask
+	return existingTask, nil
+}
+
+func (r *memoryTaskRepository) DeleteTaskByID(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.get(id); !ok {
+		return ErrTaskNotFound
+	}
+	deletedAt := gorm.DeletedAt{Time: time.Now(), Valid: true}
+	for _, id := range r.subtree(id) {
+		task := r.tasks[id]
+		task.DeletedAt = deletedAt
+		r.tasks[id] = task
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) DeleteTasksByUserID(ctx context.Context, userID uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for id, task := range r.tasks {
+		if task.UserID == userID && !task.DeletedAt.Valid {
+			task.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+			r.tasks[id] = task
+		}
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) GetTasksByUserID(ctx context.Context, userID uint) ([]Task, error) {
+	return r.find(ctx, func(task Task) bool { return task.UserID == userID })
+}
+
+func (r *memoryTaskRepository) ListTasks(ctx context.Context, query TaskQuery) ([]Task, error) {
+	tasks, err := r.find(ctx, query.TaskFilter.match)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.Slice(tasks, query.Query, func(task Task) pagination.Position {
+		return positionOf(task, query.SortBy)
+	}), nil
+}
+
+func (r *memoryTaskRepository) CountTasks(ctx context.Context, filter TaskFilter) (int64, error) {
+	tasks, err := r.find(ctx, filter.match)
+	return int64(len(tasks)), err
+}
+
+// SearchTasks ищет простым совпадением подстрок, как SQLite
+func (r *memoryTaskRepository) SearchTasks(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	tasks, err := r.find(ctx, func(task Task) bool { return task.UserID == query.UserID })
+	if err != nil {
+		return nil, err
+	}
+	return searchFallback(tasks, query), nil
+}
+
+func (r *memoryTaskRepository) DueReminders(ctx context.Context, now time.Time, limit int) ([]Task, error) {
+	tasks, err := r.find(ctx, func(task Task) bool {
+		return task.RemindAt != nil && !task.RemindAt.After(now) && task.RemindedAt == nil && !task.IsDone &&
+			(task.RemindClaimedUntil == nil || !task.RemindClaimedUntil.After(now))
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].RemindAt.Before(*tasks[j].RemindAt) })
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+	return tasks, nil
+}
+
+func (r *memoryTaskRepository) ClaimReminders(ctx context.Context, ids []uint, until time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		if task, ok := r.tasks[id]; ok {
+			task.RemindClaimedUntil = &until
+			task.RemindAttempts++
+			r.tasks[id] = task
+		}
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) MarkReminded(ctx context.Context, ids []uint, at time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		if task, ok := r.tasks[id]; ok {
+			task.RemindedAt = &at
+			r.tasks[id] = task
+		}
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) AttachLabels(ctx context.Context, taskID uint, labelIDs []uint) error {
+	return r.updateLabels(ctx, taskID, func(ids []uint) []uint {
+		for _, id := range labelIDs {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	})
+}
+
+func (r *memoryTaskRepository) DetachLabels(ctx context.Context, taskID uint, labelIDs []uint) error {
+	return r.updateLabels(ctx, taskID, func(ids []uint) []uint {
+		return slices.DeleteFunc(ids, func(id uint) bool { return slices.Contains(labelIDs, id) })
+	})
+}
+
+// updateLabels меняет ID меток задачи. change получает копию, так что снимки
+// для отката транзакции не портятся
+func (r *memoryTaskRepository) updateLabels(ctx context.Context, taskID uint, change func(ids []uint) []uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.get(taskID)
+	if !ok {
+		return ErrTaskNotFound
+	}
+	task.Labels = labelRefs(change(labelIDs(task.Labels)))
+	r.tasks[taskID] = task
+	return nil
+}
+
+func (r *memoryTaskRepository) GetSubtree(ctx context.Context, id uint) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	ids := r.subtree(id)
+	tasks := make([]Task, 0, len(ids))
+	for _, id := range ids {
+		tasks = append(tasks, r.tasks[id])
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return r.withLabels(tasks), nil
+}
+
+func (r *memoryTaskRepository) GetAncestors(ctx context.Context, id uint) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Идём вверх по родителям. seen защищает от цикла, если он всё же окажется в данных
+	r.mu.RLock()
+	chain := []Task{}
+	seen := map[uint]bool{id: true}
+	task, ok := r.get(id)
+	for ok && task.ParentID != nil && !seen[*task.ParentID] {
+		if task, ok = r.get(*task.ParentID); ok {
+			seen[task.ID] = true
+			chain = append(chain, task)
+		}
+	}
+	r.mu.RUnlock()
+
+	return r.withLabels(chain), nil
+}
+
+// LockUserTasks ничего не делает: транзакции в памяти и так идут по одной
+func (r *memoryTaskRepository) LockUserTasks(ctx context.Context, _ uint) error {
+	return ctx.Err()
+}
+
+func (r *memoryTaskRepository) AddDependency(ctx context.Context, blockerID, blockedID uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Как внешние ключи и CHECK в task_dependencies
+	if _, ok := r.tasks[blockerID]; !ok {
+		return apperr.Invalid("blocker_id", "task does not exist")
+	}
+	if _, ok := r.tasks[blockedID]; !ok {
+		return apperr.Invalid("blocked_id", "task does not exist")
+	}
+	if blockerID == blockedID {
+		return apperr.Invalid("blocker_id", "task cannot block itself")
+	}
+	r.dependencies[TaskDependency{BlockerID: blockerID, BlockedID: blockedID}] = true
+	return nil
+}
+
+func (r *memoryTaskRepository) RemoveDependency(ctx context.Context, blockerID, blockedID uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	dependency := TaskDependency{BlockerID: blockerID, BlockedID: blockedID}
+	if !r.dependencies[dependency] {
+		return ErrDependencyNotFound
+	}
+	delete(r.dependencies, dependency)
+	return nil
+}
+
+func (r *memoryTaskRepository) GetBlockers(ctx context.Context, id uint) ([]Task, error) {
+	r.mu.RLock()
+	blockers := map[uint]bool{}
+	for dependency := range r.dependencies {
+		if dependency.BlockedID == id {
+			blockers[dependency.BlockerID] = true
+		}
+	}
+	r.mu.RUnlock()
+
+	return r.find(ctx, func(task Task) bool { return blockers[task.ID] })
+}
+
+func (r *memoryTaskRepository) GetDependencies(ctx context.Context, userID uint) ([]TaskDependency, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	dependencies := []TaskDependency{}
+	for dependency := range r.dependencies {
+		blocker, blockerOK := r.get(dependency.BlockerID)
+		blocked, blockedOK := r.get(dependency.BlockedID)
+		if blockerOK && blockedOK && blocker.UserID == userID && blocked.UserID == userID {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(dependencies, func(i, j int) bool {
+		a, b := dependencies[i], dependencies[j]
+		return a.BlockerID < b.BlockerID || a.BlockerID == b.BlockerID && a.BlockedID < b.BlockedID
+	})
+	return dependencies, nil
+}
+
+func (r *memoryTaskRepository) BlockedTaskIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	blocked := r.blocked()
+	r.mu.RUnlock()
+
+	result := []uint{}
+	for _, id := range ids {
+		if blocked[id] && !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// Snapshot запоминает задачи и связи между ними для отката транзакции.
+// Счётчик ID не откатывается, как и последовательность в базе
+func (r *memoryTaskRepository) Snapshot() func() {
+	r.mu.RLock()
+	saved := maps.Clone(r.tasks)
+	savedDependencies := maps.Clone(r.dependencies)
+	r.mu.RUnlock()
+	return func() {
+		r.mu.Lock()
+		r.tasks = saved
+		r.dependencies = savedDependencies
+		r.mu.Unlock()
+	}
+}
+
+// blocked - ID задач, у которых есть несделанные неудалённые блокирующие. Вызывать под блокировкой
+func (r *memoryTaskRepository) blocked() map[uint]bool {
+	blocked := map[uint]bool{}
+	for dependency := range r.dependencies {
+		if blocker, ok := r.get(dependency.BlockerID); ok && !blocker.IsDone {
+			blocked[dependency.BlockedID] = true
+		}
+	}
+	return blocked
+}
+
+// get возвращает неудалённую задачу. Вызывать под блокировкой
+func (r *memoryTaskRepository) get(id uint) (Task, bool) {
+	task, ok := r.tasks[id]
+	if !ok || task.DeletedAt.Valid {
+		return Task{}, false
+	}
+	return task, true
+}
+
+// subtree - ID неудалённой задачи и всех её подзадач, обходом в ширину, как рекурсивный
+// CTE в gorm-версии. Вызывать под блокировкой
+func (r *memoryTaskRepository) subtree(id uint) []uint {
+	if _, ok := r.get(id); !ok {
+		return nil
+	}
+	children := map[uint][]uint{}
+	for _, task := range r.tasks {
+		if task.ParentID != nil && !task.DeletedAt.Valid {
+			children[*task.ParentID] = append(children[*task.ParentID], task.ID)
+		}
+	}
+	ids := []uint{id}
+	seen := map[uint]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// find отбирает неудалённые задачи в порядке ID. Пустой результат - пустой срез, как у gorm.
+// match видит задачи уже с метками и с Blocked, его проверяет фильтр по блокировке
+func (r *memoryTaskRepository) find(ctx context.Context, match func(Task) bool) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	blocked := r.blocked()
+	all := make([]Task, 0, len(r.tasks))
+	for _, task := range r.tasks {
+		if !task.DeletedAt.Valid {
+			task.Blocked = blocked[task.ID]
+			all = append(all, task)
+		}
+	}
+	r.mu.RUnlock()
+
+	tasks := []Task{}
+	for _, task := range r.withLabels(all) {
+		if match(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// withLabels подставляет в задачи метки по их ID. Ходит в репозиторий меток,
+// поэтому вызывать без своей блокировки
+func (r *memoryTaskRepository) withLabels(tasks []Task) []Task {
+	r.mu.RLock()
+	lookup := r.labels
+	r.mu.RUnlock()
+
+	for i := range tasks {
+		ids := labelIDs(tasks[i].Labels)
+		tasks[i].Labels = []Label{}
+		if lookup != nil && len(ids) > 0 {
+			tasks[i].Labels = lookup(ids)
+		}
+	}
+	return tasks
+}
+
+// labelRefs - метки, от которых есть только ID: так они хранятся в задачах
+func labelRefs(ids []uint) []Label {
+	labels := make([]Label, 0, len(ids))
+	for _, id := range ids {
+		labels = append(labels, Label{ID: id})
+	}
+	return labels
+}
@@ -0,0 +1,36 @@
+package taskService_test
+
+import (
+	"sync"
+	"testing"
+
+	"pet1/internal/taskService"
+	"pet1/internal/taskService/repositorytest"
+)
+
+func TestMemoryTaskRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Harness {
+		repo := taskService.NewMemoryTaskRepository()
+		// Пользователей заменяет множество ID, как строки users для внешнего ключа
+		var (
+			mu     sync.Mutex
+			users  = map[uint]bool{}
+			lastID uint
+		)
+		repo.SetUserLookup(func(id uint) bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return users[id]
+		})
+		return repositorytest.Harness{
+			Repo: repo,
+			NewUser: func(t *testing.T) uint {
+				mu.Lock()
+				defer mu.Unlock()
+				lastID++
+				users[lastID] = true
+				return lastID
+			},
+		}
+	})
+}
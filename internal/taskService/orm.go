@@ -1,10 +1,65 @@
 package taskService
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Task struct {
 	gorm.Model
 	Task   string `json:"task"`
 	IsDone bool   `json:"is_done"`
-	UserID uint   `json:"user_id"`
+	UserID uint   `json:"user_id" gorm:"not null;index"`
+	// ParentID - задача, подзадачей которой является эта. nil - задача верхнего уровня
+	ParentID *uint `json:"parent_id" gorm:"index"`
+	// Priority - от PriorityHighest (P0) до PriorityLowest (P3)
+	Priority int `json:"priority" gorm:"not null"`
+	// DueAt - срок задачи, nil - без срока
+	DueAt *time.Time `json:"due_at" gorm:"index"`
+	// RemindAt - когда напомнить о задаче, nil - не напоминать
+	RemindAt *time.Time `json:"remind_at" gorm:"index:,where:reminded_at IS NULL AND deleted_at IS NULL"`
+	// RemindedAt - когда напоминание отправлено. Сбрасывается, если RemindAt поменяли
+	RemindedAt *time.Time `json:"-"`
+	// RemindClaimedUntil - до каких пор напоминание забрал планировщик. Пока срок
+	// не вышел, другие проходы его не берут
+	RemindClaimedUntil *time.Time `json:"-"`
+	// RemindAttempts - сколько раз планировщик забирал напоминание
+	RemindAttempts int `json:"-" gorm:"not null;default:0"`
+	// EstimateMinutes - оценка задачи в минутах, nil - не оценена
+	EstimateMinutes *int `json:"estimate_minutes"`
+	// Labels - метки задачи. Читаются через Preload одним запросом на всю выборку
+	Labels []Label `json:"labels" gorm:"many2many:task_labels"`
+	// Blocked - есть несделанные задачи, которые блокируют эту. В базе не хранится,
+	// его проставляет сервис
+	Blocked bool `json:"blocked" gorm:"-"`
+}
+
+// Label - метка задач. У каждого пользователя свои, имена не повторяются
+type Label struct {
+	ID     uint   `json:"id" gorm:"primaryKey"`
+	UserID uint   `json:"user_id" gorm:"not null;uniqueIndex:idx_labels_user_id_name"`
+	Name   string `json:"name" gorm:"not null;uniqueIndex:idx_labels_user_id_name"`
+	// Color - цвет в виде #rrggbb
+	Color     string    `json:"color" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TaskLabel - связь задачи с меткой, строка task_labels
+type TaskLabel struct {
+	TaskID  uint `gorm:"primaryKey"`
+	LabelID uint `gorm:"primaryKey;index"`
+}
+
+// TaskDependency - связь «BlockerID блокирует BlockedID», строка task_dependencies
+type TaskDependency struct {
+	BlockerID uint `gorm:"primaryKey"`
+	BlockedID uint `gorm:"primaryKey;index"`
+}
+
+// DBManagedColumns - колонки tasks, которые считает сама база: поисковый вектор
+// в postgres генерируется из task, приложение его не читает и не пишет
+func (*Task) DBManagedColumns() []string {
+	return []string{"search_vector"}
 }
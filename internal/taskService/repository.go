@@ -1,23 +1,76 @@
 package taskService
 
 import (
+	"context"
 	"errors"
+	"pet1/internal/apperr"
+	"pet1/internal/pagination"
+	"pet1/internal/transaction"
+	"slices"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository interface {
 	// CreateTask - Передаем в функцию task типа Task из orm.go
 	// возвращаем созданный Task и ошибку
-	CreateTask(task Task) (Task, error)
+	CreateTask(ctx context.Context, task Task) (Task, error)
 	// GetAllTasks - Возвращаем массив из всех задач в БД и ошибку
-	GetAllTasks() ([]Task, error)
+	GetAllTasks(ctx context.Context) ([]Task, error)
+	// GetTaskByID - Возвращаем задачу по ID или ErrTaskNotFound
+	GetTaskByID(ctx context.Context, id uint) (Task, error)
 	// UpdateTaskByID - Передаем id и Task, возвращаем обновленный Task
-	// и ошибку
-	UpdateTaskByID(id uint, task Task) (Task, error)
-	// DeleteTaskByID - Передаем id для удаления, возвращаем только ошибку
-	DeleteTaskByID(id uint) error
-	GetTasksByUserID(userID uint) ([]Task, error)
+	// и ошибку. IsDone, Priority, ParentID и EstimateMinutes записываются как есть:
+	// что из них не меняется, сервис берёт из текущей задачи
+	UpdateTaskByID(ctx context.Context, id uint, task Task) (Task, error)
+	// DeleteTaskByID - Передаем id для удаления, возвращаем только ошибку.
+	// Подзадачи на всех уровнях удаляются вместе с задачей
+	DeleteTaskByID(ctx context.Context, id uint) error
+	GetTasksByUserID(ctx context.Context, userID uint) ([]Task, error)
+	// DeleteTasksByUserID - удаляем все задачи пользователя, например вместе с ним самим
+	DeleteTasksByUserID(ctx context.Context, userID uint) error
+	// ListTasks - страница задач по фильтру, в порядке обхода (см. pagination.Query)
+	ListTasks(ctx context.Context, query TaskQuery) ([]Task, error)
+	// CountTasks - сколько всего задач под фильтром
+	CountTasks(ctx context.Context, filter TaskFilter) (int64, error)
+	// SearchTasks - задачи пользователя, подходящие под поисковый запрос, самые релевантные первыми
+	SearchTasks(ctx context.Context, query SearchQuery) ([]SearchResult, error)
+	// DueReminders - не больше limit несделанных задач, напоминание о которых наступило
+	// к now, ещё не отправлено и не забрано планировщиком до момента позже now.
+	// В postgres строки блокируются до конца транзакции, и другие реплики их пропускают
+	DueReminders(ctx context.Context, now time.Time, limit int) ([]Task, error)
+	// ClaimReminders забирает напоминания по задачам ids до момента until
+	// и прибавляет каждому попытку
+	ClaimReminders(ctx context.Context, ids []uint, until time.Time) error
+	// MarkReminded отмечает напоминания по задачам отправленными в момент at
+	MarkReminded(ctx context.Context, ids []uint, at time.Time) error
+	// AttachLabels вешает метки на задачу. Уже висящие метки не мешают
+	AttachLabels(ctx context.Context, taskID uint, labelIDs []uint) error
+	// DetachLabels снимает метки с задачи. Каких не было, тех и нет
+	DetachLabels(ctx context.Context, taskID uint, labelIDs []uint) error
+	// GetSubtree - задача и все её подзадачи на всех уровнях, в порядке ID.
+	// Задачи нет - пустой срез
+	GetSubtree(ctx context.Context, id uint) ([]Task, error)
+	// GetAncestors - предки задачи от родителя до корня. У задачи верхнего уровня их нет
+	GetAncestors(ctx context.Context, id uint) ([]Task, error)
+	// LockUserTasks до конца транзакции заставляет ждать другие транзакции, которые
+	// вызвали его для того же пользователя: так проверки дерева и связей его задач
+	// не гоняются друг с другом. Вызывается первым в транзакции
+	LockUserTasks(ctx context.Context, userID uint) error
+	// AddDependency отмечает, что blockerID блокирует blockedID. Уже существующая связь не мешает.
+	// На циклы не проверяет, это дело сервиса
+	AddDependency(ctx context.Context, blockerID, blockedID uint) error
+	// RemoveDependency убирает связь или возвращает ErrDependencyNotFound
+	RemoveDependency(ctx context.Context, blockerID, blockedID uint) error
+	// GetBlockers - неудалённые задачи, которые блокируют задачу id, сделанные тоже, в порядке ID
+	GetBlockers(ctx context.Context, id uint) ([]Task, error)
+	// GetDependencies - все связи между неудалёнными задачами пользователя
+	GetDependencies(ctx context.Context, userID uint) ([]TaskDependency, error)
+	// BlockedTaskIDs - те из задач ids, у которых есть несделанные блокирующие задачи
+	BlockedTaskIDs(ctx context.Context, ids []uint) ([]uint, error)
 }
 
 type taskRepository struct {
@@ -29,29 +82,59 @@ func NewTaskRepository(db *gorm.DB) *taskRepository {
 }
 
 // (r *taskRepository) привязывает данную функцию к нашему репозиторию
-func (r *taskRepository) CreateTask(task Task) (Task, error) {
-	result := r.db.Create(&task)
+func (r *taskRepository) CreateTask(ctx context.Context, task Task) (Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.CreateTask")
+	defer span.End()
+
+	// Labels.* пропускает сохранение самих меток: создаются только связи в task_labels
+	result := transaction.Conn(ctx, r.db).Omit("Labels.*").Create(&task)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+			// Задачу пытаются привязать к несуществующему пользователю
+			return Task{}, apperr.Invalid("user_id", "user does not exist")
+		}
 		return Task{}, result.Error
 	}
 	return task, nil
 }
 
-func (r *taskRepository) GetAllTasks() ([]Task, error) {
+func (r *taskRepository) GetAllTasks(ctx context.Context) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetAllTasks")
+	defer span.End()
+
 	var tasks []Task
-	err := r.db.Find(&tasks).Error
+	err := withLabels(transaction.Conn(ctx, r.db)).Find(&tasks).Error
 	return tasks, err
 }
 
+// GetTaskByID получает задачу по ее ID
+func (r *taskRepository) GetTaskByID(ctx context.Context, id uint) (Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetTaskByID")
+	defer span.End()
+
+	var task Task
+	result := withLabels(transaction.Conn(ctx, r.db)).First(&task, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Task{}, ErrTaskNotFound
+		}
+		return Task{}, result.Error
+	}
+	return task, nil
+}
+
 // UpdateTaskByID обновляет задачу по ее ID
-func (r *taskRepository) UpdateTaskByID(id uint, task Task) (Task, error) {
+func (r *taskRepository) UpdateTaskByID(ctx context.Context, id uint, task Task) (Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.UpdateTaskByID")
+	defer span.End()
+
 	// Ищем задачу в базе данных по ID
 	var existingTask Task
-	result := r.db.First(&existingTask, id)
+	result := transaction.Conn(ctx, r.db).First(&existingTask, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			// Если задача не найдена, возвращаем ошибку
-			return Task{}, errors.New("task not found")
+			return Task{}, ErrTaskNotFound
 		}
 		// Если возникла другая ошибка при поиске, возвращаем её
 		return Task{}, result.Error
@@ -62,9 +145,13 @@ func (r *taskRepository) UpdateTaskByID(id uint, task Task) (Task, error) {
 		existingTask.Task = task.Task
 	}
 	existingTask.IsDone = task.IsDone
+	existingTask.Priority = task.Priority
+	existingTask.ParentID = task.ParentID
+	existingTask.EstimateMinutes = task.EstimateMinutes
+	applyDates(&existingTask, task)
 
-	// Сохраняем обновленную задачу в базе данных
-	saveResult := r.db.Save(&existingTask)
+	// Сохраняем обновленную задачу в базе данных. Метки меняются отдельно, AttachLabels и DetachLabels
+	saveResult := transaction.Conn(ctx, r.db).Omit(clause.Associations).Save(&existingTask)
 	if saveResult.Error != nil {
 		return Task{}, saveResult.Error
 	}
@@ -74,28 +161,32 @@ func (r *taskRepository) UpdateTaskByID(id uint, task Task) (Task, error) {
 }
 
 // DeleteTaskByID удаляет задачу по ее ID
-func (r *taskRepository) DeleteTaskByID(id uint) error {
+func (r *taskRepository) DeleteTaskByID(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.DeleteTaskByID")
+	defer span.End()
+
 	// Ищем задачу в базе данных по ID
 	var task Task
-	result := r.db.First(&task, id)
+	result := transaction.Conn(ctx, r.db).First(&task, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			// Если задача не найдена, возвращаем ошибку
-			return errors.New("task not found")
+			return ErrTaskNotFound
 		}
 		// Если возникла другая ошибка при поиске, возвращаем её
 		return result.Error
 	}
 
-	// Удаляем задачу из базы данных
-	deleteResult := r.db.Delete(&task)
+	// Удаляем задачу из базы данных вместе с поддеревом. Удаление мягкое,
+	// так что ON DELETE CASCADE у parent_id здесь не сработает
+	deleteResult := transaction.Conn(ctx, r.db).Where("id IN (?)", r.subtreeIDs(ctx, id)).Delete(&Task{})
 	if deleteResult.Error != nil {
 		return deleteResult.Error
 	}
 
 	// Проверяем, была ли удалена хотя бы одна запись
 	if deleteResult.RowsAffected == 0 {
-		return errors.New("no task was deleted")
+		return ErrTaskNotFound
 	}
 
 	// Возвращаем nil, указывая на отсутствие ошибки
@@ -103,11 +194,376 @@ func (r *taskRepository) DeleteTaskByID(id uint) error {
 }
 
 // GetTasksByUserID получает все задачи пользователя по его ID
-func (r *taskRepository) GetTasksByUserID(userID uint) ([]Task, error) {
+func (r *taskRepository) GetTasksByUserID(ctx context.Context, userID uint) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetTasksByUserID")
+	defer span.End()
+
 	var tasks []Task
-	result := r.db.Where("user_id = ?", userID).Find(&tasks)
+	result := withLabels(transaction.Conn(ctx, r.db)).Where("user_id = ?", userID).Find(&tasks)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return tasks, nil
 }
+
+// DeleteTasksByUserID удаляет все задачи пользователя. Задач может и не быть, это не ошибка
+func (r *taskRepository) DeleteTasksByUserID(ctx context.Context, userID uint) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.DeleteTasksByUserID")
+	defer span.End()
+
+	return transaction.Conn(ctx, r.db).Where("user_id = ?", userID).Delete(&Task{}).Error
+}
+
+// ListTasks отбирает страницу задач
+func (r *taskRepository) ListTasks(ctx context.Context, query TaskQuery) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.ListTasks")
+	defer span.End()
+
+	tasks := []Task{}
+	if err := withLabels(pagination.Apply(r.filtered(ctx, query.TaskFilter), query.Query)).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CountTasks считает задачи под фильтром
+func (r *taskRepository) CountTasks(ctx context.Context, filter TaskFilter) (int64, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.CountTasks")
+	defer span.End()
+
+	var total int64
+	err := r.filtered(ctx, filter).Count(&total).Error
+	return total, err
+}
+
+// DueReminders отбирает напоминания, которые пора отправить, самые старые первыми
+func (r *taskRepository) DueReminders(ctx context.Context, now time.Time, limit int) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.DueReminders")
+	defer span.End()
+
+	tx := transaction.Conn(ctx, r.db).
+		Where("remind_at <= ? AND reminded_at IS NULL AND is_done = ?", now.UTC(), false).
+		Where("remind_claimed_until IS NULL OR remind_claimed_until <= ?", now.UTC()).
+		Order("remind_at, id").
+		Limit(limit)
+	// В SQLite FOR UPDATE нет, там пишущая транзакция и так одна на всю базу
+	if tx.Dialector.Name() == "postgres" {
+		tx = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+	}
+
+	tasks := []Task{}
+	if err := tx.Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ClaimReminders, как и MarkReminded, не трогает updated_at
+func (r *taskRepository) ClaimReminders(ctx context.Context, ids []uint, until time.Time) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.ClaimReminders")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return transaction.Conn(ctx, r.db).Model(&Task{}).Where("id IN ?", ids).UpdateColumns(map[string]any{
+		"remind_claimed_until": until.UTC(),
+		"remind_attempts":      gorm.Expr("remind_attempts + 1"),
+	}).Error
+}
+
+// MarkReminded проставляет reminded_at, не трогая updated_at: задачу никто не менял
+func (r *taskRepository) MarkReminded(ctx context.Context, ids []uint, at time.Time) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.MarkReminded")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return transaction.Conn(ctx, r.db).Model(&Task{}).Where("id IN ?", ids).UpdateColumn("reminded_at", at.UTC()).Error
+}
+
+// searchSQL - полнотекстовый поиск в postgres по генерируемой колонке search_vector
+// (см. миграцию add_task_search). websearch_to_tsquery не падает на любом вводе,
+// так что запрос клиента можно передавать как есть
+const searchSQL = `
+SELECT id, task, is_done, user_id, parent_id, priority, due_at, remind_at, reminded_at, estimate_minutes,
+	created_at, updated_at, deleted_at,
+	ts_rank(search_vector, query) AS rank,
+	ts_headline('tasks_multilingual', task, query,
+		'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MinWords=5, MaxWords=20') AS headline
+FROM tasks, websearch_to_tsquery('tasks_multilingual', ?) AS query
+WHERE user_id = ? AND deleted_at IS NULL AND search_vector @@ query
+ORDER BY rank DESC, id DESC
+LIMIT ?`
+
+// searchCandidates - сколько самых новых задач, прошедших LIKE, SQLite отдаёт на ранжирование в Go
+const searchCandidates = 1000
+
+// SearchTasks ищет по индексу tsvector в postgres. В SQLite индекса нет, и LIKE там
+// не сворачивает регистр кириллицы: каждое слово запроса отбирает кандидатов шаблоном
+// из likePattern с запасом, а точное совпадение, релевантность и выделение считаются
+// в Go. Кандидатов не больше searchCandidates, так что в очень длинном списке совпадений
+// старые задачи могут не попасть в выдачу
+func (r *taskRepository) SearchTasks(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.SearchTasks")
+	defer span.End()
+
+	conn := transaction.Conn(ctx, r.db)
+	if conn.Dialector.Name() != "postgres" {
+		tx := withLabels(conn).Where("user_id = ?", query.UserID)
+		for _, term := range strings.Fields(strings.ToLower(query.Text)) {
+			tx = tx.Where(`task LIKE ? ESCAPE '\'`, likePattern(term))
+		}
+		tasks := []Task{}
+		if err := tx.Order("id DESC").Limit(searchCandidates).Find(&tasks).Error; err != nil {
+			return nil, err
+		}
+		return searchFallback(tasks, query), nil
+	}
+
+	results := []SearchResult{}
+	if err := conn.Raw(searchSQL, query.Text, query.UserID, query.Limit).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	// Raw не умеет Preload, метки найденных задач дочитываем вторым запросом
+	ids := make([]uint, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	var labeled []Task
+	if err := withLabels(conn).Where("id IN ?", ids).Find(&labeled).Error; err != nil {
+		return nil, err
+	}
+	labels := make(map[uint][]Label, len(labeled))
+	for _, task := range labeled {
+		labels[task.ID] = task.Labels
+	}
+	for i := range results {
+		results[i].Labels = labels[results[i].ID]
+		results[i].Headline = escapeHeadline(results[i].Headline)
+	}
+	return results, nil
+}
+
+// filtered - запрос к задачам с условиями фильтра, общий для ListTasks и CountTasks.
+// Границы времени приводятся к UTC: SQLite сравнивает время как строки, и смещение
+// часового пояса из запроса клиента сломало бы сравнение
+func (r *taskRepository) filtered(ctx context.Context, filter TaskFilter) *gorm.DB {
+	tx := transaction.Conn(ctx, r.db).Model(&Task{}).Where("user_id = ?", filter.UserID)
+	if filter.IsDone != nil {
+		tx = tx.Where("is_done = ?", *filter.IsDone)
+	}
+	for _, bound := range []struct {
+		condition string
+		value     *time.Time
+	}{
+		{"created_at >= ?", filter.CreatedAfter},
+		{"created_at < ?", filter.CreatedBefore},
+		{"updated_at >= ?", filter.UpdatedAfter},
+		{"updated_at < ?", filter.UpdatedBefore},
+		{"due_at >= ?", filter.DueAfter},
+		{"due_at < ?", filter.DueBefore},
+	} {
+		if bound.value != nil {
+			tx = tx.Where(bound.condition, bound.value.UTC())
+		}
+	}
+	if len(filter.Labels) > 0 {
+		labels := slices.Compact(slices.Sorted(slices.Values(filter.Labels)))
+		linked := transaction.Conn(ctx, r.db).Model(&TaskLabel{}).Select("task_id").Where("label_id IN ?", labels)
+		if filter.AllLabels {
+			// Связь задачи с меткой одна, так что все метки есть, если связей столько же, сколько меток
+			linked = linked.Group("task_id").Having("COUNT(*) = ?", len(labels))
+		}
+		tx = tx.Where("id IN (?)", linked)
+	}
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			tx = tx.Where("id IN (?)", r.blockedIDs(ctx))
+		} else {
+			tx = tx.Where("id NOT IN (?)", r.blockedIDs(ctx))
+		}
+	}
+	return tx
+}
+
+// AttachLabels добавляет связи задачи с метками, пропуская уже существующие
+func (r *taskRepository) AttachLabels(ctx context.Context, taskID uint, labelIDs []uint) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.AttachLabels")
+	defer span.End()
+
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	links := make([]TaskLabel, 0, len(labelIDs))
+	for _, labelID := range labelIDs {
+		links = append(links, TaskLabel{TaskID: taskID, LabelID: labelID})
+	}
+	return transaction.Conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+// DetachLabels удаляет связи задачи с метками
+func (r *taskRepository) DetachLabels(ctx context.Context, taskID uint, labelIDs []uint) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.DetachLabels")
+	defer span.End()
+
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	return transaction.Conn(ctx, r.db).Where("task_id = ? AND label_id IN ?", taskID, labelIDs).Delete(&TaskLabel{}).Error
+}
+
+// subtreeSQL - ID задачи и всех её подзадач. UNION, а не UNION ALL: если в данных
+// всё же окажется цикл, рекурсия остановится, когда новых строк не останется
+const subtreeSQL = `
+WITH RECURSIVE subtree (id) AS (
+	SELECT id FROM tasks WHERE id = ? AND deleted_at IS NULL
+	UNION
+	SELECT tasks.id FROM tasks JOIN subtree ON tasks.parent_id = subtree.id
+	WHERE tasks.deleted_at IS NULL
+)
+SELECT id FROM subtree`
+
+// ancestorsSQL - ID задачи и всех её предков, так же защищённый от циклов
+const ancestorsSQL = `
+WITH RECURSIVE ancestors (id, parent_id) AS (
+	SELECT id, parent_id FROM tasks WHERE id = ? AND deleted_at IS NULL
+	UNION
+	SELECT tasks.id, tasks.parent_id FROM tasks JOIN ancestors ON tasks.id = ancestors.parent_id
+	WHERE tasks.deleted_at IS NULL
+)
+SELECT id FROM ancestors`
+
+// GetSubtree читает поддерево одним запросом: рекурсивный CTE отбирает ID,
+// а метки подгружаются Preload на всю выборку
+func (r *taskRepository) GetSubtree(ctx context.Context, id uint) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetSubtree")
+	defer span.End()
+
+	tasks := []Task{}
+	err := withLabels(transaction.Conn(ctx, r.db)).Where("id IN (?)", r.subtreeIDs(ctx, id)).Order("id").Find(&tasks).Error
+	return tasks, err
+}
+
+// GetAncestors читает цепочку предков рекурсивным CTE и выстраивает её от родителя к корню
+func (r *taskRepository) GetAncestors(ctx context.Context, id uint) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetAncestors")
+	defer span.End()
+
+	var tasks []Task
+	ids := transaction.Conn(ctx, r.db).Raw(ancestorsSQL, id)
+	if err := withLabels(transaction.Conn(ctx, r.db)).Where("id IN (?)", ids).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return ancestorChain(tasks, id), nil
+}
+
+// userTasksLockClass - первый ключ advisory lock для LockUserTasks, чтобы не
+// пересекаться с другими блокировками того же вида
+const userTasksLockClass = 1001
+
+// LockUserTasks берёт в postgres транзакционный advisory lock по пользователю. Вторым
+// ключом идёт int4, так что ID больше 2^31 делят блокировку с другими - это только
+// лишнее ожидание. В SQLite пишущая транзакция и так одна на всю базу, а транзакцию,
+// которая прочитала данные до чужой записи, Do повторит после SQLITE_BUSY
+func (r *taskRepository) LockUserTasks(ctx context.Context, userID uint) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.LockUserTasks")
+	defer span.End()
+
+	conn := transaction.Conn(ctx, r.db)
+	if conn.Dialector.Name() != "postgres" {
+		return nil
+	}
+	return conn.Exec("SELECT pg_advisory_xact_lock(?, ?)", userTasksLockClass, int32(userID)).Error
+}
+
+// subtreeIDs - подзапрос с ID поддерева задачи
+func (r *taskRepository) subtreeIDs(ctx context.Context, id uint) *gorm.DB {
+	return transaction.Conn(ctx, r.db).Raw(subtreeSQL, id)
+}
+
+// AddDependency вставляет связь, пропуская уже существующую
+func (r *taskRepository) AddDependency(ctx context.Context, blockerID, blockedID uint) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.AddDependency")
+	defer span.End()
+
+	dependency := TaskDependency{BlockerID: blockerID, BlockedID: blockedID}
+	return transaction.Conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&dependency).Error
+}
+
+// RemoveDependency удаляет связь
+func (r *taskRepository) RemoveDependency(ctx context.Context, blockerID, blockedID uint) error {
+	ctx, span := tracer.Start(ctx, "TaskRepository.RemoveDependency")
+	defer span.End()
+
+	result := transaction.Conn(ctx, r.db).Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&TaskDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDependencyNotFound
+	}
+	return nil
+}
+
+// GetBlockers читает блокирующие задачи с метками, подзапросом к task_dependencies
+func (r *taskRepository) GetBlockers(ctx context.Context, id uint) ([]Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetBlockers")
+	defer span.End()
+
+	tasks := []Task{}
+	blockers := transaction.Conn(ctx, r.db).Model(&TaskDependency{}).Select("blocker_id").Where("blocked_id = ?", id)
+	err := withLabels(transaction.Conn(ctx, r.db)).Where("id IN (?)", blockers).Order("id").Find(&tasks).Error
+	return tasks, err
+}
+
+// GetDependencies читает связи одним запросом. Задачи одной связи всегда у одного
+// владельца, так что достаточно смотреть на владельца блокируемой
+func (r *taskRepository) GetDependencies(ctx context.Context, userID uint) ([]TaskDependency, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetDependencies")
+	defer span.End()
+
+	dependencies := []TaskDependency{}
+	err := transaction.Conn(ctx, r.db).Model(&TaskDependency{}).
+		Select("task_dependencies.blocker_id, task_dependencies.blocked_id").
+		Joins("JOIN tasks AS blockers ON blockers.id = task_dependencies.blocker_id AND blockers.deleted_at IS NULL").
+		Joins("JOIN tasks AS blocked ON blocked.id = task_dependencies.blocked_id AND blocked.deleted_at IS NULL").
+		Where("blocked.user_id = ?", userID).
+		Order("task_dependencies.blocker_id, task_dependencies.blocked_id").
+		Find(&dependencies).Error
+	return dependencies, err
+}
+
+// BlockedTaskIDs отбирает заблокированные задачи одним запросом на всю выборку
+func (r *taskRepository) BlockedTaskIDs(ctx context.Context, ids []uint) ([]uint, error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.BlockedTaskIDs")
+	defer span.End()
+
+	blocked := []uint{}
+	if len(ids) == 0 {
+		return blocked, nil
+	}
+	err := r.blockedIDs(ctx).Where("task_dependencies.blocked_id IN ?", ids).Distinct().Pluck("task_dependencies.blocked_id", &blocked).Error
+	return blocked, err
+}
+
+// blockedIDs - подзапрос с ID задач, у которых есть несделанные неудалённые блокирующие
+func (r *taskRepository) blockedIDs(ctx context.Context) *gorm.DB {
+	return transaction.Conn(ctx, r.db).Model(&TaskDependency{}).
+		Select("task_dependencies.blocked_id").
+		Joins("JOIN tasks AS blockers ON blockers.id = task_dependencies.blocker_id").
+		Where("blockers.is_done = ? AND blockers.deleted_at IS NULL", false)
+}
+
+// withLabels подгружает метки задач по имени. Preload делает один запрос на всю выборку
+// через task_labels, а не по запросу на задачу
+func withLabels(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Labels", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("labels.name")
+	})
+}
@@ -0,0 +1,37 @@
+package taskService_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"pet1/internal/db/dbtest"
+	"pet1/internal/taskService"
+	"pet1/internal/taskService/repositorytest"
+	"pet1/internal/userService"
+)
+
+func TestTaskRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Harness {
+		conn := dbtest.SQLite(t)
+		users := userService.NewUserRepository(conn)
+		return repositorytest.Harness{
+			Repo: taskService.NewTaskRepository(conn),
+			NewUser: func(t *testing.T) uint {
+				t.Helper()
+				count, err := users.CountUsers(context.Background(), userService.UserFilter{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				user, err := users.CreateUser(context.Background(), userService.User{
+					Email:        fmt.Sprintf("owner%d@example.com", count+1),
+					PasswordHash: "hash",
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return user.ID
+			},
+		}
+	})
+}
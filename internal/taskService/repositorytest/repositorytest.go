@@ -0,0 +1,488 @@
+// Package repositorytest - общий набор тестов для реализаций taskService.TaskRepository.
+// Любая реализация должна вести себя одинаково: так сервисы и хендлеры можно
+// проверять на репозитории в памяти, а работать они будут с базой
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"pet1/internal/apperr"
+	"pet1/internal/taskService"
+)
+
+// Harness - репозиторий под проверкой и его окружение
+type Harness struct {
+	Repo taskService.TaskRepository
+	// NewUser заводит владельца задач и возвращает его ID. Задачи с владельцем,
+	// которого так не заводили, репозиторий создавать не должен
+	NewUser func(t *testing.T) uint
+}
+
+// Run прогоняет набор на репозиториях из setup. setup вызывается на каждый тест
+// и должен отдавать пустой репозиторий
+func Run(t *testing.T, setup func(t *testing.T) Harness) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, h Harness)
+	}{
+		{"CreateAssignsIncreasingIDs", testCreateAssignsIncreasingIDs},
+		{"CreateRejectsUnknownUser", testCreateRejectsUnknownUser},
+		{"NotFound", testNotFound},
+		{"Update", testUpdate},
+		{"SoftDelete", testSoftDelete},
+		{"DeleteRemovesSubtree", testDeleteRemovesSubtree},
+		{"DeleteTasksByUserID", testDeleteTasksByUserID},
+		{"Dependencies", testDependencies},
+		{"Search", testSearch},
+		{"Reminders", testReminders},
+		{"ContextDone", testContextDone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, setup(t))
+		})
+	}
+}
+
+func testCreateAssignsIncreasingIDs(t *testing.T, h Harness) {
+	ctx := context.Background()
+	userID := h.NewUser(t)
+
+	first := create(t, h, taskService.Task{Task: "first", UserID: userID})
+	second := create(t, h, taskService.Task{Task: "second", UserID: userID})
+	if first.ID == 0 || second.ID <= first.ID {
+		t.Fatalf("IDs = %d, %d, want increasing and non-zero", first.ID, second.ID)
+	}
+	if first.CreatedAt.IsZero() || first.UpdatedAt.IsZero() {
+		t.Errorf("timestamps are not set: %+v", first)
+	}
+
+	// ID удалённой задачи не выдаётся повторно, как и значение последовательности
+	if err := h.Repo.DeleteTaskByID(ctx, second.ID); err != nil {
+		t.Fatal(err)
+	}
+	third := create(t, h, taskService.Task{Task: "third", UserID: userID})
+	if third.ID <= second.ID {
+		t.Errorf("ID after delete = %d, want greater than %d", third.ID, second.ID)
+	}
+
+	got, err := h.Repo.GetTaskByID(ctx, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Task != "first" || got.UserID != userID || got.IsDone {
+		t.Errorf("GetTaskByID = %+v, want the created task", got)
+	}
+}
+
+func testCreateRejectsUnknownUser(t *testing.T, h Harness) {
+	_, err := h.Repo.CreateTask(context.Background(), taskService.Task{Task: "orphan", UserID: h.NewUser(t) + 100})
+	if !errors.Is(err, apperr.ErrValidation) {
+		t.Errorf("CreateTask with unknown user: err = %v, want validation error", err)
+	}
+}
+
+func testNotFound(t *testing.T, h Harness) {
+	ctx := context.Background()
+	const missing = 4242
+
+	if _, err := h.Repo.GetTaskByID(ctx, missing); !errors.Is(err, taskService.ErrTaskNotFound) {
+		t.Errorf("GetTaskByID: err = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := h.Repo.UpdateTaskByID(ctx, missing, taskService.Task{Task: "x"}); !errors.Is(err, taskService.ErrTaskNotFound) {
+		t.Errorf("UpdateTaskByID: err = %v, want ErrTaskNotFound", err)
+	}
+	if err := h.Repo.DeleteTaskByID(ctx, missing); !errors.Is(err, taskService.ErrTaskNotFound) {
+		t.Errorf("DeleteTaskByID: err = %v, want ErrTaskNotFound", err)
+	}
+	if err := h.Repo.RemoveDependency(ctx, missing, missing+1); !errors.Is(err, taskService.ErrDependencyNotFound) {
+		t.Errorf("RemoveDependency: err = %v, want ErrDependencyNotFound", err)
+	}
+	// Пустые выборки - не ошибка
+	if tasks, err := h.Repo.GetTasksByUserID(ctx, missing); err != nil || len(tasks) != 0 {
+		t.Errorf("GetTasksByUserID = %v, %v, want no tasks", tasks, err)
+	}
+	if tasks, err := h.Repo.GetSubtree(ctx, missing); err != nil || len(tasks) != 0 {
+		t.Errorf("GetSubtree = %v, %v, want no tasks", tasks, err)
+	}
+}
+
+func testUpdate(t *testing.T, h Harness) {
+	ctx := context.Background()
+	userID := h.NewUser(t)
+	task := create(t, h, taskService.Task{Task: "write tests", UserID: userID})
+
+	estimate := 30
+	updated, err := h.Repo.UpdateTaskByID(ctx, task.ID, taskService.Task{
+		IsDone: true, Priority: taskService.PriorityHighest, EstimateMinutes: &estimate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Пустой текст означает «не менять»
+	if updated.Task != "write tests" || !updated.IsDone || updated.Priority != taskService.PriorityHighest {
+		t.Errorf("UpdateTaskByID = %+v", updated)
+	}
+
+	got, err := h.Repo.GetTaskByID(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Task != "write tests" || !got.IsDone || got.EstimateMinutes == nil || *got.EstimateMinutes != estimate {
+		t.Errorf("GetTaskByID after update = %+v", got)
+	}
+	if got.ID != task.ID || got.UserID != userID {
+		t.Errorf("update changed identity: %+v", got)
+	}
+}
+
+func testSoftDelete(t *testing.T, h Harness) {
+	ctx := context.Background()
+	userID := h.NewUser(t)
+	kept := create(t, h, taskService.Task{Task: "kept", UserID: userID})
+	deleted := create(t, h, taskService.Task{Task: "deleted", UserID: userID})
+
+	if err := h.Repo.DeleteTaskByID(ctx, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+	// Удалённая задача больше нигде не находится, и удалить её второй раз нельзя
+	if _, err := h.Repo.GetTaskByID(ctx, deleted.ID); !errors.Is(err, taskService.ErrTaskNotFound) {
+		t.Errorf("GetTaskByID of deleted task: err = %v, want ErrTaskNotFound", err)
+	}
+	if _, err := h.Repo.UpdateTaskByID(ctx, deleted.ID, taskService.Task{Task: "revived"}); !errors.Is(err, taskService.ErrTaskNotFound) {
+		t.Errorf("UpdateTaskByID of deleted task: err = %v, want ErrTaskNotFound", err)
+	}
+	if err := h.Repo.DeleteTaskByID(ctx, deleted.ID); !errors.Is(err, taskService.ErrTaskNotFound) {
+		t.Errorf("second DeleteTaskByID: err = %v, want ErrTaskNotFound", err)
+	}
+
+	all, err := h.Repo.GetAllTasks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(all); !slices.Equal(got, []uint{kept.ID}) {
+		t.Errorf("GetAllTasks IDs = %v, want [%d]", got, kept.ID)
+	}
+	own, err := h.Repo.GetTasksByUserID(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(own); !slices.Equal(got, []uint{kept.ID}) {
+		t.Errorf("GetTasksByUserID IDs = %v, want [%d]", got, kept.ID)
+	}
+	if total, err := h.Repo.CountTasks(ctx, taskService.TaskFilter{UserID: userID}); err != nil || total != 1 {
+		t.Errorf("CountTasks = %d, %v, want 1", total, err)
+	}
+}
+
+func testDeleteRemovesSubtree(t *testing.T, h Harness) {
+	ctx := context.Background()
+	userID := h.NewUser(t)
+	root := create(t, h, taskService.Task{Task: "root", UserID: userID})
+	child := create(t, h, taskService.Task{Task: "child", UserID: userID, ParentID: &root.ID})
+	grandchild := create(t, h, taskService.Task{Task: "grandchild", UserID: userID, ParentID: &child.ID})
+	other := create(t, h, taskService.Task{Task: "other", UserID: userID})
+
+	subtree, err := h.Repo.GetSubtree(ctx, root.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(subtree); !slices.Equal(got, []uint{root.ID, child.ID, grandchild.ID}) {
+		t.Errorf("GetSubtree IDs = %v", got)
+	}
+	ancestors, err := h.Repo.GetAncestors(ctx, grandchild.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(ancestors); !slices.Equal(got, []uint{child.ID, root.ID}) {
+		t.Errorf("GetAncestors IDs = %v, want parent first", got)
+	}
+
+	if err := h.Repo.DeleteTaskByID(ctx, child.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []uint{child.ID, grandchild.ID} {
+		if _, err := h.Repo.GetTaskByID(ctx, id); !errors.Is(err, taskService.ErrTaskNotFound) {
+			t.Errorf("task %d after deleting its subtree: err = %v, want ErrTaskNotFound", id, err)
+		}
+	}
+	for _, id := range []uint{root.ID, other.ID} {
+		if _, err := h.Repo.GetTaskByID(ctx, id); err != nil {
+			t.Errorf("task %d outside the subtree: %v", id, err)
+		}
+	}
+}
+
+func testDeleteTasksByUserID(t *testing.T, h Harness) {
+	ctx := context.Background()
+	owner, other := h.NewUser(t), h.NewUser(t)
+	create(t, h, taskService.Task{Task: "mine", UserID: owner})
+	create(t, h, taskService.Task{Task: "mine too", UserID: owner})
+	theirs := create(t, h, taskService.Task{Task: "theirs", UserID: other})
+
+	if err := h.Repo.DeleteTasksByUserID(ctx, owner); err != nil {
+		t.Fatal(err)
+	}
+	// Пользователь без задач - не ошибка
+	if err := h.Repo.DeleteTasksByUserID(ctx, owner); err != nil {
+		t.Errorf("DeleteTasksByUserID without tasks: %v", err)
+	}
+
+	if tasks, err := h.Repo.GetTasksByUserID(ctx, owner); err != nil || len(tasks) != 0 {
+		t.Errorf("owner tasks = %v, %v, want none", tasks, err)
+	}
+	tasks, err := h.Repo.GetTasksByUserID(ctx, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(tasks); !slices.Equal(got, []uint{theirs.ID}) {
+		t.Errorf("other user's tasks = %v, want [%d]", got, theirs.ID)
+	}
+}
+
+func testDependencies(t *testing.T, h Harness) {
+	ctx := context.Background()
+	userID := h.NewUser(t)
+	blocker := create(t, h, taskService.Task{Task: "blocker", UserID: userID})
+	blocked := create(t, h, taskService.Task{Task: "blocked", UserID: userID})
+
+	// Повторная связь не ошибка и не дубль
+	for range 2 {
+		if err := h.Repo.AddDependency(ctx, blocker.ID, blocked.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dependencies, err := h.Repo.GetDependencies(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []taskService.TaskDependency{{BlockerID: blocker.ID, BlockedID: blocked.ID}}
+	if !slices.Equal(dependencies, want) {
+		t.Errorf("GetDependencies = %v, want %v", dependencies, want)
+	}
+	if got, err := h.Repo.BlockedTaskIDs(ctx, []uint{blocker.ID, blocked.ID}); err != nil || !slices.Equal(got, []uint{blocked.ID}) {
+		t.Errorf("BlockedTaskIDs = %v, %v, want [%d]", got, err, blocked.ID)
+	}
+
+	// Сделанная блокирующая задача больше не держит
+	if _, err := h.Repo.UpdateTaskByID(ctx, blocker.ID, taskService.Task{IsDone: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := h.Repo.BlockedTaskIDs(ctx, []uint{blocked.ID}); err != nil || len(got) != 0 {
+		t.Errorf("BlockedTaskIDs with done blocker = %v, %v, want none", got, err)
+	}
+	blockers, err := h.Repo.GetBlockers(ctx, blocked.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(blockers); !slices.Equal(got, []uint{blocker.ID}) {
+		t.Errorf("GetBlockers = %v, want done blocker too", got)
+	}
+
+	if err := h.Repo.RemoveDependency(ctx, blocker.ID, blocked.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Repo.RemoveDependency(ctx, blocker.ID, blocked.ID); !errors.Is(err, taskService.ErrDependencyNotFound) {
+		t.Errorf("second RemoveDependency: err = %v, want ErrDependencyNotFound", err)
+	}
+}
+
+func testSearch(t *testing.T, h Harness) {
+	ctx := context.Background()
+	userID, other := h.NewUser(t), h.NewUser(t)
+	milk := create(t, h, taskService.Task{Task: "Купить молоко", UserID: userID})
+	bread := create(t, h, taskService.Task{Task: "КУПИТЬ хлеб и Молоко", UserID: userID})
+	percent := create(t, h, taskService.Task{Task: "buy 100% MILK", UserID: userID})
+	underscore := create(t, h, taskService.Task{Task: "fix progress_bar", UserID: userID})
+	create(t, h, taskService.Task{Task: "unrelated", UserID: userID})
+	create(t, h, taskService.Task{Task: "купить молоко", UserID: other})
+
+	tests := []struct {
+		text  string
+		limit int
+		want  []uint
+	}{
+		// Регистр кириллицы не важен, при равной релевантности новые первыми
+		{"купить молоко", 10, []uint{bread.ID, milk.ID}},
+		{"МОЛОКО", 10, []uint{bread.ID, milk.ID}},
+		{"купить", 1, []uint{bread.ID}},
+		{"milk", 10, []uint{percent.ID}},
+		// % и _ в запросе - обычные символы, а не шаблон
+		{"%", 10, []uint{percent.ID}},
+		{"x_bar", 10, nil},
+		{"_bar", 10, []uint{underscore.ID}},
+		{"молоко nothing", 10, nil},
+	}
+	for _, tt := range tests {
+		results, err := h.Repo.SearchTasks(ctx, taskService.SearchQuery{UserID: userID, Text: tt.text, Limit: tt.limit})
+		if err != nil {
+			t.Fatalf("SearchTasks(%q): %v", tt.text, err)
+		}
+		got := []uint{}
+		for _, result := range results {
+			got = append(got, result.ID)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SearchTasks(%q) IDs = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	results, err := h.Repo.SearchTasks(ctx, taskService.SearchQuery{UserID: userID, Text: "milk", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Headline == "" || results[0].Rank <= 0 {
+		t.Errorf("SearchTasks(milk) = %+v, want one ranked result with headline", results)
+	}
+}
+
+func testReminders(t *testing.T, h Harness) {
+	ctx := context.Background()
+	userID := h.NewUser(t)
+	now := time.Date(2025, 2, 11, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	later := create(t, h, taskService.Task{Task: "later", UserID: userID, RemindAt: at(-time.Minute)})
+	first := create(t, h, taskService.Task{Task: "first", UserID: userID, RemindAt: at(-time.Hour)})
+	create(t, h, taskService.Task{Task: "future", UserID: userID, RemindAt: at(time.Hour)})
+	create(t, h, taskService.Task{Task: "done", UserID: userID, IsDone: true, RemindAt: at(-time.Hour)})
+	create(t, h, taskService.Task{Task: "no reminder", UserID: userID})
+
+	due := func(now time.Time) []uint {
+		t.Helper()
+		tasks, err := h.Repo.DueReminders(ctx, now, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ids(tasks)
+	}
+	if got := due(now); !slices.Equal(got, []uint{first.ID, later.ID}) {
+		t.Fatalf("DueReminders = %v, want %v in remind_at order", got, []uint{first.ID, later.ID})
+	}
+	if tasks, err := h.Repo.DueReminders(ctx, now, 1); err != nil || !slices.Equal(ids(tasks), []uint{first.ID}) {
+		t.Errorf("DueReminders with limit 1 = %v, %v, want [%d]", ids(tasks), err, first.ID)
+	}
+
+	// Забранное напоминание скрыто до конца срока, и попытка засчитана
+	if err := h.Repo.ClaimReminders(ctx, []uint{first.ID}, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if got := due(now); !slices.Equal(got, []uint{later.ID}) {
+		t.Errorf("DueReminders while claimed = %v, want [%d]", got, later.ID)
+	}
+	tasks, err := h.Repo.DueReminders(ctx, now.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids(tasks), []uint{first.ID, later.ID}) || tasks[0].RemindAttempts != 1 {
+		t.Errorf("DueReminders after the claim expired = %+v, want both, the first with one attempt", tasks)
+	}
+
+	// Отправленное не возвращается, а новое время напоминания начинает всё заново
+	if err := h.Repo.MarkReminded(ctx, []uint{first.ID, later.ID}, now); err != nil {
+		t.Fatal(err)
+	}
+	if got := due(now.Add(time.Hour)); len(got) != 1 || got[0] == first.ID || got[0] == later.ID {
+		t.Errorf("DueReminders after marking = %v, want only the future reminder", got)
+	}
+	if _, err := h.Repo.UpdateTaskByID(ctx, first.ID, taskService.Task{RemindAt: at(-time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+	tasks, err = h.Repo.DueReminders(ctx, now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids(tasks), []uint{first.ID}) || tasks[0].RemindAttempts != 0 {
+		t.Errorf("DueReminders after a new remind_at = %+v, want the task again with no attempts", tasks)
+	}
+}
+
+func testContextDone(t *testing.T, h Harness) {
+	userID := h.NewUser(t)
+	task := create(t, h, taskService.Task{Task: "untouched", UserID: userID})
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"CreateTask", func(ctx context.Context) error {
+			_, err := h.Repo.CreateTask(ctx, taskService.Task{Task: "new", UserID: userID})
+			return err
+		}},
+		{"GetTaskByID", func(ctx context.Context) error {
+			_, err := h.Repo.GetTaskByID(ctx, task.ID)
+			return err
+		}},
+		{"UpdateTaskByID", func(ctx context.Context) error {
+			_, err := h.Repo.UpdateTaskByID(ctx, task.ID, taskService.Task{Task: "changed", IsDone: true})
+			return err
+		}},
+		{"DeleteTaskByID", func(ctx context.Context) error {
+			return h.Repo.DeleteTaskByID(ctx, task.ID)
+		}},
+		{"GetTasksByUserID", func(ctx context.Context) error {
+			_, err := h.Repo.GetTasksByUserID(ctx, userID)
+			return err
+		}},
+		{"CountTasks", func(ctx context.Context) error {
+			_, err := h.Repo.CountTasks(ctx, taskService.TaskFilter{UserID: userID})
+			return err
+		}},
+	}
+	for _, done := range []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"cancelled", cancelled, context.Canceled},
+		{"expired", expired, context.DeadlineExceeded},
+	} {
+		for _, c := range calls {
+			if err := c.call(done.ctx); !errors.Is(err, done.want) {
+				t.Errorf("%s with %s context: err = %v, want %v", c.name, done.name, err, done.want)
+			}
+		}
+	}
+
+	// Ни один из прерванных вызовов ничего не поменял
+	got, err := h.Repo.GetTaskByID(context.Background(), task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Task != "untouched" || got.IsDone {
+		t.Errorf("task after aborted calls = %+v, want it unchanged", got)
+	}
+	if total, err := h.Repo.CountTasks(context.Background(), taskService.TaskFilter{UserID: userID}); err != nil || total != 1 {
+		t.Errorf("CountTasks after aborted calls = %d, %v, want 1", total, err)
+	}
+}
+
+// create создаёт задачу или останавливает тест
+func create(t *testing.T, h Harness, task taskService.Task) taskService.Task {
+	t.Helper()
+	created, err := h.Repo.CreateTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("CreateTask(%q): %v", task.Task, err)
+	}
+	return created
+}
+
+func ids(tasks []taskService.Task) []uint {
+	result := make([]uint, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, task.ID)
+	}
+	return result
+}
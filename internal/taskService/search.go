@@ -0,0 +1,198 @@
+package taskService
+
+import (
+	"cmp"
+	"context"
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"pet1/internal/apperr"
+	"pet1/internal/pagination"
+	"pet1/internal/policy"
+)
+
+// maxSearchLength - ограничение длины поискового запроса
+const maxSearchLength = 200
+
+// Совпадения в headline выделяются этими тегами, всё остальное экранируется для HTML
+const (
+	markStart = "<mark>"
+	markEnd   = "</mark>"
+)
+
+// SearchParams - поисковый запрос в том виде, в каком он пришёл от клиента
+type SearchParams struct {
+	Text string
+	// Limit - сколько результатов вернуть, 0 - pagination.DefaultLimit
+	Limit int
+}
+
+// SearchQuery - поисковый запрос к репозиторию
+type SearchQuery struct {
+	UserID uint
+	Text   string
+	Limit  int
+}
+
+// SearchResult - найденная задача, её релевантность и фрагмент текста с выделенными совпадениями
+type SearchResult struct {
+	Task     `gorm:"embedded"`
+	Rank     float64
+	Headline string
+}
+
+// SearchTasks ищет по тексту задач вызывающего. Самые релевантные - первыми
+func (s *TaskService) SearchTasks(ctx context.Context, params SearchParams) ([]SearchResult, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.SearchTasks")
+	defer span.End()
+
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(params.Text)
+	if text == "" {
+		return nil, apperr.Invalid("q", "must not be empty")
+	}
+	if utf8.RuneCountInString(text) > maxSearchLength {
+		return nil, apperr.Invalid("q", "must be at most 200 characters")
+	}
+	if params.Limit == 0 {
+		params.Limit = pagination.DefaultLimit
+	}
+	if params.Limit < 1 || params.Limit > pagination.MaxLimit {
+		return nil, apperr.Invalid("limit", "must be between 1 and 100")
+	}
+
+	if !policy.Can(caller, policy.ReadTasks, caller.UserID) {
+		return nil, ErrForbidden
+	}
+
+	results, err := s.repo.SearchTasks(ctx, SearchQuery{UserID: caller.UserID, Text: text, Limit: params.Limit})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	blocked, err := s.repo.BlockedTaskIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Blocked = slices.Contains(blocked, results[i].ID)
+	}
+	return results, nil
+}
+
+// searchFallback - поиск для хранилищ без полнотекстового индекса: все слова запроса
+// должны встречаться в задаче как подстроки без учёта регистра. Релевантность - число
+// совпадений, при равенстве новые задачи первыми. Синтаксис веб-поиска не разбирается,
+// кавычки и минусы считаются частью слов
+func searchFallback(tasks []Task, query SearchQuery) []SearchResult {
+	terms := strings.Fields(strings.ToLower(query.Text))
+	results := []SearchResult{}
+	for _, task := range tasks {
+		headline, matches, ok := highlight(task.Task, terms)
+		if !ok {
+			continue
+		}
+		results = append(results, SearchResult{Task: task, Rank: float64(matches), Headline: headline})
+	}
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		if c := cmp.Compare(b.Rank, a.Rank); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results
+}
+
+// likePattern - шаблон LIKE, который находит слово term (в нижнем регистре) как подстроку
+// без учёта регистра. LIKE в SQLite сворачивает регистр только у ASCII, поэтому буквы
+// с регистром вне ASCII, а также i и k (в них сворачиваются İ и знак кельвина)
+// заменяются на _, любой один символ. Шаблон отбирает с запасом, точное совпадение
+// проверяет highlight. Спецсимволы LIKE экранируются обратной косой чертой
+func likePattern(term string) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, r := range term {
+		switch {
+		case r == '%' || r == '_' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == 'i' || r == 'k':
+			b.WriteByte('_')
+		case r < utf8.RuneSelf || unicode.ToLower(r) == unicode.ToUpper(r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	b.WriteByte('%')
+	return b.String()
+}
+
+// highlight выделяет в text все вхождения terms (в нижнем регистре) и возвращает
+// экранированный текст, число вхождений и нашлось ли каждое слово хотя бы раз.
+// Сравнение идёт по рунам, чтобы смена регистра не сдвигала позиции
+func highlight(text string, terms []string) (string, int, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	matches := 0
+	for _, term := range terms {
+		needle := []rune(term)
+		found := false
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if !slices.Equal(lower[i:i+len(needle)], needle) {
+				continue
+			}
+			found = true
+			matches++
+			for j := i; j < i+len(needle); j++ {
+				marked[j] = true
+			}
+		}
+		if !found {
+			return "", 0, false
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		chunk := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			chunk = markStart + chunk + markEnd
+		}
+		b.WriteString(chunk)
+		i = j
+	}
+	return b.String(), matches, true
+}
+
+// escapeHeadline экранирует фрагмент от ts_headline для HTML, оставляя только теги выделения.
+// Если в самой задаче написано <mark>, оно тоже станет выделением, но не более того
+func escapeHeadline(headline string) string {
+	escaped := html.EscapeString(headline)
+	return strings.NewReplacer(
+		html.EscapeString(markStart), markStart,
+		html.EscapeString(markEnd), markEnd,
+	).Replace(escaped)
+}
@@ -1,29 +1,344 @@
 package taskService
 
+import (
+	"context"
+	"database/sql"
+	"unicode/utf8"
+
+	"pet1/internal/apperr"
+	"pet1/internal/identity"
+	"pet1/internal/policy"
+	"pet1/internal/transaction"
+
+	"go.opentelemetry.io/otel"
+)
+
+// tracer - спаны сервиса и репозитория задач
+var tracer = otel.Tracer("pet1/internal/taskService")
+
+// maxTaskLength - ограничение колонки tasks.task VARCHAR(255)
+const maxTaskLength = 255
+
+// serializable - для транзакций, которые решают, что записать, по прочитанному: родитель
+// закрывается по готовности подзадач, и на READ COMMITTED две подзадачи, закрытые
+// одновременно, не увидели бы друг друга. Конфликт Do повторяет, поэтому функции
+// транзакций начинают каждую попытку с чистого состояния
+var serializable = transaction.WithIsolation(sql.LevelSerializable)
+
+// Metrics - счётчики предметной области, которые сервис обновляет по ходу работы
+type Metrics interface {
+	TaskCreated()
+	TaskCompleted()
+}
+
+// Users - то, что сервису задач нужно знать о пользователях.
+// Сами пользователи живут в userService, который и так зависит от задач
+type Users interface {
+	UserExists(ctx context.Context, id uint) (bool, error)
+}
+
 type TaskService struct {
-	repo TaskRepository
+	repo    TaskRepository
+	labels  LabelRepository
+	users   Users
+	tx      transaction.Manager
+	metrics Metrics
+	tree    TreeOptions
 }
 
-func NewService(repo TaskRepository) *TaskService {
-	return &TaskService{repo: repo}
+func NewService(repo TaskRepository, labels LabelRepository, users Users, tx transaction.Manager, metrics Metrics, tree TreeOptions) *TaskService {
+	return &TaskService{repo: repo, labels: labels, users: users, tx: tx, metrics: metrics, tree: tree}
 }
 
-func (s *TaskService) CreateTask(task Task) (Task, error) {
-	return s.repo.CreateTask(task)
+// CreateTask создаёт задачу. Если владелец не указан, им становится вызывающий.
+// Создавать задачи другим пользователям может только тот, кому политика разрешает писать в любые задачи.
+// Priority здесь не «не указан» при нуле, а P0: значение по умолчанию подставляет хендлер.
+// В Labels достаточно ID меток владельца задачи, ParentID - задача того же владельца
+func (s *TaskService) CreateTask(ctx context.Context, task Task) (Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.CreateTask")
+	defer span.End()
+
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return Task{}, err
+	}
+
+	if task.Task == "" {
+		return Task{}, apperr.Invalid("task", "must not be empty")
+	}
+	if err := validateTask(task); err != nil {
+		return Task{}, err
+	}
+	if err := validatePriority(task.Priority); err != nil {
+		return Task{}, err
+	}
+	if err := validateEstimate(task.EstimateMinutes); err != nil {
+		return Task{}, err
+	}
+
+	if task.UserID == 0 {
+		task.UserID = caller.UserID
+	}
+	if !policy.Can(caller, policy.WriteTasks, task.UserID) {
+		return Task{}, ErrForbidden
+	}
+
+	// Нулевое время при создании - то же, что его отсутствие. Напоминание ещё не отправлено
+	task.DueAt = clearable(task.DueAt)
+	task.RemindAt = clearable(task.RemindAt)
+	task.RemindedAt = nil
+
+	var created Task
+	completedParents := 0
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		completedParents = 0
+		// Пока проверяем родителя, другие транзакции не перестроят дерево владельца
+		if task.ParentID != nil {
+			if err := s.repo.LockUserTasks(ctx, task.UserID); err != nil {
+				return err
+			}
+		}
+		if task.Labels, err = s.labelsOf(ctx, task.UserID, "labels", labelIDs(task.Labels)); err != nil {
+			return err
+		}
+		if task.ParentID != nil {
+			if err := s.checkParent(ctx, task.UserID, 0, *task.ParentID, 1); err != nil {
+				return err
+			}
+		}
+
+		if created, err = s.repo.CreateTask(ctx, task); err != nil {
+			return err
+		}
+		// Новая подзадача может закрыть родителя, если она сделана, или открыть, если нет
+		if created.ParentID != nil && s.tree.AutoCompleteParent {
+			completedParents, err = s.syncParents(ctx, *created.ParentID)
+		}
+		return err
+	}, serializable)
+	if err != nil {
+		return Task{}, err
+	}
+
+	s.metrics.TaskCreated()
+	if created.IsDone {
+		s.metrics.TaskCompleted()
+	}
+	for range completedParents {
+		s.metrics.TaskCompleted()
+	}
+	return created, nil
+}
+
+// UpdateTaskByID обновляет задачу, её приоритет, оценку, метки и место в дереве одной транзакцией.
+// Закрыть задачу с несделанными блокирующими можно только с Force.
+// Чужая задача для вызывающего не существует
+func (s *TaskService) UpdateTaskByID(ctx context.Context, id uint, task Task, update TaskUpdate) (Task, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.UpdateTaskByID")
+	defer span.End()
+
+	if err := validateTask(task); err != nil {
+		return Task{}, err
+	}
+	if update.Priority != nil {
+		if err := validatePriority(*update.Priority); err != nil {
+			return Task{}, err
+		}
+	}
+	if err := validateEstimate(update.EstimateMinutes); err != nil {
+		return Task{}, err
+	}
+
+	// Перенос в дереве проверяется под блокировкой задач владельца, а она должна быть
+	// первой в транзакции. Владелец задачи не меняется, так что его можно узнать заранее
+	var ownerID uint
+	if update.ParentID != nil {
+		existing, err := s.getOwnTask(ctx, id)
+		if err != nil {
+			return Task{}, err
+		}
+		ownerID = existing.UserID
+	}
+
+	var existing, updated Task
+	completedParents := 0
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		completedParents = 0
+		if ownerID != 0 {
+			if err := s.repo.LockUserTasks(ctx, ownerID); err != nil {
+				return err
+			}
+		}
+		existing, err = s.getOwnTask(ctx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := s.labelsOf(ctx, existing.UserID, "add_labels", update.AttachLabels); err != nil {
+			return err
+		}
+
+		task.IsDone = existing.IsDone
+		if update.IsDone != nil {
+			task.IsDone = *update.IsDone
+		}
+		task.Priority = existing.Priority
+		if update.Priority != nil {
+			task.Priority = *update.Priority
+		}
+		task.EstimateMinutes = existing.EstimateMinutes
+		if update.EstimateMinutes != nil {
+			task.EstimateMinutes = update.EstimateMinutes
+		}
+		if update.ClearEstimate {
+			task.EstimateMinutes = nil
+		}
+
+		// Поддерево задачи нужно, только если её переносят или закрывают
+		moved := update.ParentID != nil && !sameParent(existing.ParentID, *update.ParentID)
+		// Задача закрывается или открывается, только если is_done прислали и он другой
+		statusChanged := update.IsDone != nil && *update.IsDone != existing.IsDone
+		completing := statusChanged && *update.IsDone
+		var subtree TaskNode
+		if moved || completing && s.tree.BlockOpenSubtasks {
+			tasks, err := s.repo.GetSubtree(ctx, id)
+			if err != nil {
+				return err
+			}
+			subtree = buildTree(tasks, id)
+		}
+
+		task.ParentID = existing.ParentID
+		if moved {
+			task.ParentID = nil
+			if *update.ParentID != 0 {
+				if err := s.checkParent(ctx, existing.UserID, id, *update.ParentID, subtree.height()); err != nil {
+					return err
+				}
+				task.ParentID = update.ParentID
+			}
+		}
+		if completing && s.tree.BlockOpenSubtasks && subtree.Progress.Done < subtree.Progress.Total {
+			return ErrOpenSubtasks
+		}
+		if completing && !update.Force {
+			blockers, err := s.openBlockers(ctx, id)
+			if err != nil {
+				return err
+			}
+			if len(blockers) > 0 {
+				return errTaskBlocked(blockers)
+			}
+		}
+
+		if _, err := s.repo.UpdateTaskByID(ctx, id, task); err != nil {
+			return err
+		}
+		if err := s.repo.AttachLabels(ctx, id, update.AttachLabels); err != nil {
+			return err
+		}
+		if err := s.repo.DetachLabels(ctx, id, update.DetachLabels); err != nil {
+			return err
+		}
+		// Родители, старый и новый, следуют за подзадачами, если задача закрылась,
+		// открылась или переехала
+		if s.tree.AutoCompleteParent && (statusChanged || moved) {
+			parents := []*uint{task.ParentID}
+			if moved {
+				parents = append(parents, existing.ParentID)
+			}
+			for _, parentID := range parents {
+				if parentID == nil {
+					continue
+				}
+				completed, err := s.syncParents(ctx, *parentID)
+				if err != nil {
+					return err
+				}
+				completedParents += completed
+			}
+		}
+		// Перечитываем, чтобы вернуть задачу с итоговым набором меток
+		if updated, err = s.repo.GetTaskByID(ctx, id); err != nil {
+			return err
+		}
+		blockers, err := s.openBlockers(ctx, id)
+		updated.Blocked = len(blockers) > 0
+		return err
+	}, serializable)
+	if err != nil {
+		return Task{}, err
+	}
+
+	// Считаем только переход из «не сделано» в «сделано», повторные PATCH не в счёт
+	if !existing.IsDone && updated.IsDone {
+		s.metrics.TaskCompleted()
+	}
+	for range completedParents {
+		s.metrics.TaskCompleted()
+	}
+	return updated, nil
 }
 
-func (s *TaskService) GetAllTasks() ([]Task, error) {
-	return s.repo.GetAllTasks()
+// DeleteTaskByID удаляет задачу вместе с подзадачами. Чужая задача для вызывающего не существует
+func (s *TaskService) DeleteTaskByID(ctx context.Context, id uint) error {
+	ctx, span := tracer.Start(ctx, "TaskService.DeleteTaskByID")
+	defer span.End()
+
+	completedParents := 0
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		task, err := s.getOwnTask(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.DeleteTaskByID(ctx, id); err != nil {
+			return err
+		}
+		// Удалённая подзадача могла быть последней несделанной у родителя
+		if task.ParentID != nil && s.tree.AutoCompleteParent {
+			completedParents, err = s.syncParents(ctx, *task.ParentID)
+		}
+		return err
+	}, serializable)
+	if err != nil {
+		return err
+	}
+	for range completedParents {
+		s.metrics.TaskCompleted()
+	}
+	return nil
 }
 
-func (s *TaskService) UpdateTaskByID(id uint, task Task) (Task, error) {
-	return s.repo.UpdateTaskByID(id, task)
+// getOwnTask возвращает задачу, если вызывающему разрешено её менять.
+// Иначе отвечаем ErrTaskNotFound, а не 403, чтобы не раскрывать, что такая задача есть
+func (s *TaskService) getOwnTask(ctx context.Context, id uint) (Task, error) {
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task, err := s.repo.GetTaskByID(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	if !policy.Can(caller, policy.WriteTasks, task.UserID) {
+		return Task{}, ErrTaskNotFound
+	}
+	return task, nil
 }
 
-func (s *TaskService) DeleteTaskByID(id uint) error {
-	return s.repo.DeleteTaskByID(id)
+func validateTask(task Task) error {
+	if utf8.RuneCountInString(task.Task) > maxTaskLength {
+		return apperr.Invalid("task", "must be at most 255 characters")
+	}
+	return nil
 }
 
-func (s *TaskService) GetTasksByUserID(userID uint) ([]Task, error) {
-	return s.repo.GetTasksByUserID(userID)
+func callerFrom(ctx context.Context) (identity.Caller, error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return identity.Caller{}, ErrUnauthenticated
+	}
+	return caller, nil
 }
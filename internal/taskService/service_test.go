@@ -0,0 +1,158 @@
+package taskService_test
+
+import (
+	"context"
+	"testing"
+
+	"pet1/internal/identity"
+	"pet1/internal/taskService"
+	"pet1/internal/transaction"
+)
+
+// owner - единственный пользователь в тестах сервиса
+const owner uint = 1
+
+type users struct{}
+
+func (users) UserExists(_ context.Context, id uint) (bool, error) {
+	return id == owner, nil
+}
+
+type metrics struct{}
+
+func (metrics) TaskCreated()   {}
+func (metrics) TaskCompleted() {}
+
+// newService собирает сервис на репозиториях в памяти и контекст от имени owner
+func newService(t *testing.T, tree taskService.TreeOptions) (*taskService.TaskService, context.Context) {
+	t.Helper()
+	tasks := taskService.NewMemoryTaskRepository()
+	tasks.SetUserLookup(func(id uint) bool { return id == owner })
+	labels := taskService.NewMemoryLabelRepository()
+	tasks.SetLabelLookup(labels.Lookup)
+	service := taskService.NewService(tasks, labels, users{}, transaction.NewMemoryManager(tasks, labels), metrics{}, tree)
+	ctx := identity.NewContext(context.Background(), identity.Caller{UserID: owner, Role: identity.RoleMember})
+	return service, ctx
+}
+
+func TestUpdateKeepsIsDoneUnlessSent(t *testing.T) {
+	service, ctx := newService(t, taskService.TreeOptions{MaxDepth: 5})
+	task, err := service.CreateTask(ctx, taskService.Task{Task: "ship it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := true
+	if _, err := service.UpdateTaskByID(ctx, task.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &done}); err != nil {
+		t.Fatal(err)
+	}
+
+	// PATCH только с приоритетом не открывает задачу заново
+	priority := 2
+	updated, err := service.UpdateTaskByID(ctx, task.ID, taskService.Task{}, taskService.TaskUpdate{Priority: &priority})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.IsDone || updated.Priority != priority {
+		t.Errorf("after priority-only update: is_done = %v, priority = %d, want true, %d", updated.IsDone, updated.Priority, priority)
+	}
+
+	notDone := false
+	if updated, err = service.UpdateTaskByID(ctx, task.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &notDone}); err != nil {
+		t.Fatal(err)
+	}
+	if updated.IsDone {
+		t.Error("is_done = false was not applied")
+	}
+}
+
+func TestPriorityUpdateKeepsParentDone(t *testing.T) {
+	service, ctx := newService(t, taskService.TreeOptions{MaxDepth: 5, AutoCompleteParent: true})
+	parent, err := service.CreateTask(ctx, taskService.Task{Task: "release"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done, open := true, false
+	child, err := service.CreateTask(ctx, taskService.Task{Task: "changelog", ParentID: &parent.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sibling, err := service.CreateTask(ctx, taskService.Task{Task: "tag", ParentID: &parent.ID, IsDone: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.UpdateTaskByID(ctx, child.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &done}); err != nil {
+		t.Fatal(err)
+	}
+	assertDone(t, service, ctx, parent.ID, true)
+
+	// Родителя, закрытого по подзадачам, приоритет не трогает ни у подзадачи, ни у него самого
+	priority := 1
+	for _, id := range []uint{child.ID, parent.ID} {
+		if _, err := service.UpdateTaskByID(ctx, id, taskService.Task{}, taskService.TaskUpdate{Priority: &priority}); err != nil {
+			t.Fatal(err)
+		}
+		assertDone(t, service, ctx, parent.ID, true)
+	}
+
+	// Родителя закрыли вручную при открытой подзадаче: правка её приоритета
+	// не повод пересчитывать его заново
+	if _, err := service.UpdateTaskByID(ctx, sibling.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &open}); err != nil {
+		t.Fatal(err)
+	}
+	assertDone(t, service, ctx, parent.ID, false)
+	if _, err := service.UpdateTaskByID(ctx, parent.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &done}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.UpdateTaskByID(ctx, sibling.ID, taskService.Task{}, taskService.TaskUpdate{Priority: &priority}); err != nil {
+		t.Fatal(err)
+	}
+	assertDone(t, service, ctx, parent.ID, true)
+}
+
+func assertDone(t *testing.T, service *taskService.TaskService, ctx context.Context, id uint, want bool) {
+	t.Helper()
+	node, err := service.GetTaskTree(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Task.IsDone != want {
+		t.Fatalf("task %d is_done = %v, want %v", id, node.Task.IsDone, want)
+	}
+}
+
+func TestRollupStopsAtBlockedParent(t *testing.T) {
+	service, ctx := newService(t, taskService.TreeOptions{MaxDepth: 5, AutoCompleteParent: true})
+	create := func(text string, parentID *uint) taskService.Task {
+		t.Helper()
+		task, err := service.CreateTask(ctx, taskService.Task{Task: text, ParentID: parentID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return task
+	}
+	release := create("release", nil)
+	deploy := create("deploy", &release.ID)
+	migrate := create("migrate", &deploy.ID)
+	approval := create("approval", nil)
+	if err := service.AddBlocker(ctx, deploy.ID, approval.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Последняя подзадача сделана, но deploy ждёт approval: он не закрывается,
+	// и release выше тоже
+	done := true
+	if _, err := service.UpdateTaskByID(ctx, migrate.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &done}); err != nil {
+		t.Fatal(err)
+	}
+	assertDone(t, service, ctx, deploy.ID, false)
+	assertDone(t, service, ctx, release.ID, false)
+
+	// Блокирующая сделана - deploy закрывают вручную, и подъём идёт дальше
+	if _, err := service.UpdateTaskByID(ctx, approval.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &done}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.UpdateTaskByID(ctx, deploy.ID, taskService.Task{}, taskService.TaskUpdate{IsDone: &done}); err != nil {
+		t.Fatal(err)
+	}
+	assertDone(t, service, ctx, release.ID, true)
+}
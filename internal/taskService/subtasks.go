@@ -0,0 +1,256 @@
+package taskService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pet1/internal/apperr"
+	"pet1/internal/policy"
+)
+
+// TreeOptions - правила для подзадач
+type TreeOptions struct {
+	// MaxDepth - сколько уровней может быть в дереве, считая корневую задачу
+	MaxDepth int
+	// AutoCompleteParent - родитель становится сделанным, когда сделаны все его подзадачи,
+	// и снова несделанным, когда среди них появляется несделанная
+	AutoCompleteParent bool
+	// BlockOpenSubtasks - задачу нельзя отметить сделанной, пока не сделаны все её подзадачи
+	BlockOpenSubtasks bool
+}
+
+// Progress - сколько подзадач на всех уровнях под задачей и сколько из них сделано
+type Progress struct {
+	Total int
+	Done  int
+	// Percent - доля сделанных в процентах, округлённая вниз. Без подзадач - 0
+	Percent int
+}
+
+// TaskNode - задача в дереве вместе с прогрессом по её подзадачам
+type TaskNode struct {
+	Task     Task
+	Progress Progress
+	Children []TaskNode
+}
+
+// GetSubtasks возвращает прямые подзадачи задачи в порядке ID, у каждой свой прогресс
+func (s *TaskService) GetSubtasks(ctx context.Context, id uint) ([]TaskNode, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetSubtasks")
+	defer span.End()
+
+	tree, err := s.GetTaskTree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	subtasks := tree.Children
+	for i := range subtasks {
+		subtasks[i].Children = nil
+	}
+	return subtasks, nil
+}
+
+// GetTaskTree возвращает задачу со всеми подзадачами. Поддерево читается одним
+// рекурсивным запросом, а вложенность и прогресс собираются уже здесь
+func (s *TaskService) GetTaskTree(ctx context.Context, id uint) (TaskNode, error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetTaskTree")
+	defer span.End()
+
+	if _, err := s.getVisibleTask(ctx, id); err != nil {
+		return TaskNode{}, err
+	}
+	subtree, err := s.repo.GetSubtree(ctx, id)
+	if err != nil {
+		return TaskNode{}, err
+	}
+	if err := s.markBlocked(ctx, subtree); err != nil {
+		return TaskNode{}, err
+	}
+	return buildTree(subtree, id), nil
+}
+
+// getVisibleTask возвращает задачу, если вызывающему разрешено её читать.
+// Как и в getOwnTask, невидимая задача для него просто не существует
+func (s *TaskService) getVisibleTask(ctx context.Context, id uint) (Task, error) {
+	caller, err := callerFrom(ctx)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task, err := s.repo.GetTaskByID(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+	if !policy.Can(caller, policy.ReadTasks, task.UserID) {
+		return Task{}, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// checkParent проверяет, что задачу taskID с поддеревом высотой height можно
+// положить под parentID: родитель есть у того же владельца, не лежит в самом
+// поддереве и дерево не станет глубже MaxDepth. У новой задачи taskID - 0
+func (s *TaskService) checkParent(ctx context.Context, ownerID, taskID, parentID uint, height int) error {
+	if taskID != 0 && parentID == taskID {
+		return ErrTaskCycle
+	}
+	parent, err := s.repo.GetTaskByID(ctx, parentID)
+	if err != nil && !errors.Is(err, ErrTaskNotFound) {
+		return err
+	}
+	// Класть задачи под чужие нельзя, даже тому, кто может писать в чужие задачи
+	if err != nil || parent.UserID != ownerID {
+		return apperr.Invalid("parent_id", fmt.Sprintf("task %d does not exist", parentID))
+	}
+
+	ancestors, err := s.repo.GetAncestors(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == taskID {
+			return ErrTaskCycle
+		}
+	}
+	// Родитель на уровне len(ancestors)+1, поддерево задачи займёт ещё height уровней
+	if len(ancestors)+1+height > s.tree.MaxDepth {
+		return apperr.Invalid("parent_id", fmt.Sprintf("tasks can be nested at most %d levels deep", s.tree.MaxDepth))
+	}
+	return nil
+}
+
+// syncParents приводит готовность задачи parentID и её предков в соответствие
+// с подзадачами, снизу вверх: сделаны все подзадачи - задача сделана, есть
+// несделанная - нет. Задача с несделанными блокирующими сама не закрывается, как
+// и без Force в UpdateTaskByID, и выше подъём не идёт: её закроют вручную или при
+// следующей перемене в подзадачах. Возвращает, сколько задач при этом закрылось
+func (s *TaskService) syncParents(ctx context.Context, parentID uint) (int, error) {
+	parent, err := s.repo.GetTaskByID(ctx, parentID)
+	if err != nil {
+		return 0, err
+	}
+	ancestors, err := s.repo.GetAncestors(ctx, parentID)
+	if err != nil {
+		return 0, err
+	}
+	chain := append([]Task{parent}, ancestors...)
+
+	// Всё дерево от корня: в нём видны подзадачи каждого звена цепочки
+	subtree, err := s.repo.GetSubtree(ctx, chain[len(chain)-1].ID)
+	if err != nil {
+		return 0, err
+	}
+	done := make(map[uint]bool, len(subtree))
+	children := make(map[uint][]uint, len(subtree))
+	for _, task := range subtree {
+		done[task.ID] = task.IsDone
+		if task.ParentID != nil {
+			children[*task.ParentID] = append(children[*task.ParentID], task.ID)
+		}
+	}
+
+	completed := 0
+	for _, task := range chain {
+		allDone := true
+		for _, child := range children[task.ID] {
+			allDone = allDone && done[child]
+		}
+		// Задача не поменялась - не поменяются и те, кто выше. Задачу, у которой
+		// подзадач не осталось, например после переноса, не трогаем
+		if len(children[task.ID]) == 0 || task.IsDone == allDone {
+			break
+		}
+		if allDone {
+			blockers, err := s.openBlockers(ctx, task.ID)
+			if err != nil {
+				return completed, err
+			}
+			if len(blockers) > 0 {
+				break
+			}
+		}
+		task.IsDone = allDone
+		if _, err := s.repo.UpdateTaskByID(ctx, task.ID, task); err != nil {
+			return completed, err
+		}
+		done[task.ID] = allDone
+		if allDone {
+			completed++
+		}
+	}
+	return completed, nil
+}
+
+// buildTree собирает дерево с корнем rootID из задач поддерева.
+// Задачи идут по ID, поэтому и подзадачи в каждом узле упорядочены по ID
+func buildTree(tasks []Task, rootID uint) TaskNode {
+	var root Task
+	children := make(map[uint][]Task, len(tasks))
+	for _, task := range tasks {
+		if task.ID == rootID {
+			root = task
+			continue
+		}
+		if task.ParentID != nil {
+			children[*task.ParentID] = append(children[*task.ParentID], task)
+		}
+	}
+
+	// Корень ничьим ребёнком не становится, так что даже цикл в данных не зациклит обход
+	var build func(task Task) TaskNode
+	build = func(task Task) TaskNode {
+		node := TaskNode{Task: task, Children: []TaskNode{}}
+		for _, child := range children[task.ID] {
+			childNode := build(child)
+			node.Children = append(node.Children, childNode)
+			node.Progress.Total += 1 + childNode.Progress.Total
+			node.Progress.Done += childNode.Progress.Done
+			if child.IsDone {
+				node.Progress.Done++
+			}
+		}
+		if node.Progress.Total > 0 {
+			node.Progress.Percent = node.Progress.Done * 100 / node.Progress.Total
+		}
+		return node
+	}
+	return build(root)
+}
+
+// height - сколько уровней занимает поддерево узла, у задачи без подзадач - 1
+func (n TaskNode) height() int {
+	height := 0
+	for _, child := range n.Children {
+		height = max(height, child.height())
+	}
+	return height + 1
+}
+
+// sameParent - совпадает ли текущий родитель с новым, где 0 - «без родителя»
+func sameParent(current *uint, parentID uint) bool {
+	if current == nil {
+		return parentID == 0
+	}
+	return *current == parentID
+}
+
+// ancestorChain выстраивает предков задачи id из набора задач от родителя к корню
+func ancestorChain(tasks []Task, id uint) []Task {
+	byID := make(map[uint]Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	chain := []Task{}
+	seen := map[uint]bool{id: true}
+	for parentID := byID[id].ParentID; parentID != nil && !seen[*parentID]; {
+		parent, ok := byID[*parentID]
+		if !ok {
+			break
+		}
+		chain = append(chain, parent)
+		seen[parent.ID] = true
+		parentID = parent.ParentID
+	}
+	return chain
+}
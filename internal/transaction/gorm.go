@@ -0,0 +1,89 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/glebarez/go-sqlite"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// txKey - ключ контекста, под которым лежит открытая транзакция gorm
+type txKey struct{}
+
+// retryBaseDelay - пауза перед первым повтором, дальше она удваивается
+const retryBaseDelay = 10 * time.Millisecond
+
+type gormManager struct {
+	db *gorm.DB
+	// maxRetries - сколько раз повторять транзакцию после конфликта сериализации
+	maxRetries int
+}
+
+func NewGormManager(db *gorm.DB, maxRetries int) *gormManager {
+	return &gormManager{db: db, maxRetries: maxRetries}
+}
+
+// Do открывает транзакцию или, если она уже есть в контексте, точку сохранения.
+// Транзакцию, которую база отменила из-за конфликта сериализации или взаимной
+// блокировки, повторяем целиком: поэтому fn должна быть готова выполниться ещё раз.
+// SQLite уровень изоляции не настраивает: пишущая транзакция там и так одна на всю базу
+func (m *gormManager) Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		// gorm сам превращает вложенную Transaction в SAVEPOINT
+		return tx.Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		})
+	}
+
+	var txOptions []*sql.TxOptions
+	if o := newOptions(opts); o.isolation != sql.LevelDefault {
+		txOptions = append(txOptions, &sql.TxOptions{Isolation: o.isolation})
+	}
+	for attempt := 0; ; attempt++ {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		}, txOptions...)
+		if err == nil || !isRetryable(err) || attempt >= m.maxRetries {
+			return err
+		}
+
+		// Пауза с разбросом, чтобы столкнувшиеся транзакции не столкнулись снова
+		delay := retryBaseDelay<<attempt + rand.N(retryBaseDelay)
+		slog.WarnContext(ctx, "transaction conflict, retrying", "attempt", attempt+1, "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Conn возвращает транзакцию из контекста, а если её нет - обычное соединение db.
+// Репозитории gorm берут соединение только через неё
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// isRetryable распознаёт ошибки, после которых транзакцию можно просто повторить
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// serialization_failure и deadlock_detected
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		// SQLITE_BUSY и его расширенные коды: база занята другой записью
+		return sqliteErr.Code()&0xff == 5
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package transaction
+
+import (
+	"context"
+	"sync"
+)
+
+// Snapshotter - хранилище в памяти, которое умеет запомнить своё состояние.
+// Snapshot возвращает функцию, возвращающую хранилище к запомненному состоянию
+type Snapshotter interface {
+	Snapshot() (restore func())
+}
+
+// memoryKey - признак того, что контекст уже внутри транзакции в памяти
+type memoryKey struct{}
+
+// memoryManager - транзакции для хранилищ в памяти. Транзакции выполняются по одной,
+// а откат возвращает хранилища к снимку, сделанному на входе в Do.
+// Запросы вне транзакций её не ждут, так что это скорее мок, чем настоящая изоляция
+type memoryManager struct {
+	mu     sync.Mutex
+	stores []Snapshotter
+}
+
+func NewMemoryManager(stores ...Snapshotter) *memoryManager {
+	return &memoryManager{stores: stores}
+}
+
+// Do выполняет транзакции по одной, так что уровень изоляции из opts ни на что не влияет
+func (m *memoryManager) Do(ctx context.Context, fn func(ctx context.Context) error, _ ...Option) error {
+	if ctx.Value(memoryKey{}) == nil {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		ctx = context.WithValue(ctx, memoryKey{}, true)
+	}
+
+	// Вложенный Do делает свой снимок - это и есть точка сохранения
+	restores := make([]func(), len(m.stores))
+	for i, store := range m.stores {
+		restores[i] = store.Snapshot()
+	}
+	// Откатываемся и на ошибке, и на панике - как gorm
+	committed := false
+	defer func() {
+		if !committed {
+			for _, restore := range restores {
+				restore()
+			}
+		}
+	}()
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
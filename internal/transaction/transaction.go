@@ -0,0 +1,42 @@
+// Package transaction объединяет вызовы нескольких репозиториев в одну транзакцию.
+// Транзакция едет в контексте: сервис оборачивает работу в Manager.Do,
+// а репозитории берут соединение через Conn и сами попадают в транзакцию
+package transaction
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Manager выполняет fn в транзакции: если fn вернула ошибку, все изменения откатываются.
+// Вложенный Do внутри fn открывает точку сохранения, и его ошибка откатывает
+// только его часть, если внешняя функция решит эту ошибку проглотить
+type Manager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error
+}
+
+// Option - настройка транзакции, которую открывает Do
+type Option func(*options)
+
+type options struct {
+	isolation sql.IsolationLevel
+}
+
+// WithIsolation задаёт уровень изоляции транзакции. Без него действует уровень базы,
+// в postgres это READ COMMITTED: данные, прочитанные в начале fn, к записи могут
+// устареть. Там, где решение о записи принимается по прочитанному, нужен
+// sql.LevelSerializable - конфликт тогда вернётся ошибкой 40001, и Do повторит fn.
+// Вложенный Do уровень не меняет: точка сохранения живёт в транзакции снаружи
+func WithIsolation(level sql.IsolationLevel) Option {
+	return func(o *options) {
+		o.isolation = level
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,247 @@
+package transaction_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"pet1/internal/db/dbtest"
+	"pet1/internal/taskService"
+	"pet1/internal/transaction"
+	"pet1/internal/userService"
+
+	"github.com/glebarez/go-sqlite"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+var errBoom = errors.New("boom")
+
+// managers - оба менеджера транзакций вместе с репозиторием, который в них пишет
+func managers() map[string]func(t *testing.T) (transaction.Manager, userService.UserRepository) {
+	return map[string]func(t *testing.T) (transaction.Manager, userService.UserRepository){
+		"gorm": func(t *testing.T) (transaction.Manager, userService.UserRepository) {
+			conn := dbtest.SQLite(t)
+			return transaction.NewGormManager(conn, 0), userService.NewUserRepository(conn)
+		},
+		"memory": func(t *testing.T) (transaction.Manager, userService.UserRepository) {
+			users := userService.NewMemoryUserRepository(taskService.NewMemoryTaskRepository())
+			return transaction.NewMemoryManager(users), users
+		},
+	}
+}
+
+func TestRollbackOnError(t *testing.T) {
+	for name, setup := range managers() {
+		t.Run(name, func(t *testing.T) {
+			tx, users := setup(t)
+			ctx := context.Background()
+
+			// Первая запись уже сделана, когда операция падает на середине
+			err := tx.Do(ctx, func(ctx context.Context) error {
+				createUser(t, ctx, users, "first@example.com")
+				createUser(t, ctx, users, "second@example.com")
+				return errBoom
+			}, transaction.WithIsolation(sql.LevelSerializable))
+			if !errors.Is(err, errBoom) {
+				t.Fatalf("Do = %v, want errBoom", err)
+			}
+			assertEmails(t, users)
+		})
+	}
+}
+
+func TestNestedRollbackKeepsOuterWork(t *testing.T) {
+	for name, setup := range managers() {
+		t.Run(name, func(t *testing.T) {
+			tx, users := setup(t)
+			ctx := context.Background()
+
+			err := tx.Do(ctx, func(ctx context.Context) error {
+				createUser(t, ctx, users, "before@example.com")
+				// Ошибку вложенного Do внешняя функция проглатывает:
+				// откатиться должна только точка сохранения
+				inner := tx.Do(ctx, func(ctx context.Context) error {
+					createUser(t, ctx, users, "inner@example.com")
+					return errBoom
+				})
+				if !errors.Is(inner, errBoom) {
+					t.Errorf("nested Do = %v, want errBoom", inner)
+				}
+				createUser(t, ctx, users, "after@example.com")
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertEmails(t, users, "before@example.com", "after@example.com")
+		})
+	}
+}
+
+func TestOuterRollbackUndoesNestedCommit(t *testing.T) {
+	for name, setup := range managers() {
+		t.Run(name, func(t *testing.T) {
+			tx, users := setup(t)
+			ctx := context.Background()
+
+			err := tx.Do(ctx, func(ctx context.Context) error {
+				if err := tx.Do(ctx, func(ctx context.Context) error {
+					createUser(t, ctx, users, "inner@example.com")
+					return nil
+				}); err != nil {
+					return err
+				}
+				return errBoom
+			})
+			if !errors.Is(err, errBoom) {
+				t.Fatalf("Do = %v, want errBoom", err)
+			}
+			assertEmails(t, users)
+		})
+	}
+}
+
+func TestRollbackOnPanic(t *testing.T) {
+	for name, setup := range managers() {
+		t.Run(name, func(t *testing.T) {
+			tx, users := setup(t)
+			ctx := context.Background()
+
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("panic was swallowed")
+					}
+				}()
+				_ = tx.Do(ctx, func(ctx context.Context) error {
+					createUser(t, ctx, users, "panic@example.com")
+					panic(errBoom)
+				})
+			}()
+			assertEmails(t, users)
+		})
+	}
+}
+
+func createUser(t *testing.T, ctx context.Context, users userService.UserRepository, email string) {
+	t.Helper()
+	if _, err := users.CreateUser(ctx, userService.User{Email: email, PasswordHash: "hash"}); err != nil {
+		t.Fatalf("CreateUser(%q): %v", email, err)
+	}
+}
+
+// assertEmails проверяет, что после транзакции в репозитории ровно эти пользователи
+func assertEmails(t *testing.T, users userService.UserRepository, want ...string) {
+	t.Helper()
+	all, err := users.GetAllUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, user := range all {
+		got = append(got, user.Email)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("users = %v, want %v", got, want)
+	}
+}
+
+// maxRetries - с таким пределом повторов собран менеджер в тестах повторов
+const maxRetries = 3
+
+func TestRetry(t *testing.T) {
+	conn := dbtest.SQLite(t)
+	busy := sqliteBusy(t, conn)
+
+	serialization := func(attempt int) error {
+		return &pgconn.PgError{Code: "40001", Message: fmt.Sprintf("conflict on attempt %d", attempt)}
+	}
+	deadlock := func(attempt int) error {
+		return fmt.Errorf("failed to update task: %w", &pgconn.PgError{Code: "40P01", Message: fmt.Sprint(attempt)})
+	}
+
+	cases := []struct {
+		name string
+		// fail - ошибка попытки attempt (с нуля)
+		fail func(attempt int) error
+		// failures - сколько первых попыток падает
+		failures  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "serialization failure then success", fail: serialization, failures: 2, wantCalls: 3},
+		{name: "deadlock then success", fail: deadlock, failures: maxRetries, wantCalls: maxRetries + 1},
+		{name: "sqlite busy then success", fail: func(int) error { return busy }, failures: 1, wantCalls: 2},
+		{name: "retries exhausted", fail: serialization, failures: 100, wantCalls: maxRetries + 1, wantErr: true},
+		{name: "other error", fail: func(int) error { return errBoom }, failures: 100, wantCalls: 1, wantErr: true},
+		{name: "unique violation", fail: func(int) error { return &pgconn.PgError{Code: "23505"} }, failures: 100, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := transaction.NewGormManager(conn, maxRetries)
+			calls := 0
+			var last error
+			err := tx.Do(context.Background(), func(context.Context) error {
+				defer func() { calls++ }()
+				if calls < tc.failures {
+					last = tc.fail(calls)
+					return last
+				}
+				return nil
+			})
+
+			if calls != tc.wantCalls {
+				t.Errorf("fn called %d times, want %d", calls, tc.wantCalls)
+			}
+			switch {
+			case !tc.wantErr && err != nil:
+				t.Errorf("Do = %v, want success", err)
+			case tc.wantErr && err != last:
+				t.Errorf("Do = %v, want the last error %v", err, last)
+			}
+		})
+	}
+}
+
+// sqliteBusy добывает настоящую ошибку SQLITE_BUSY: вторая запись в файл, пока
+// первая транзакция держит блокировку. busy_timeout у второго соединения нулевой,
+// так что оно не ждёт, а сразу падает
+func sqliteBusy(t *testing.T, conn *gorm.DB) error {
+	t.Helper()
+	ctx := context.Background()
+	var path string
+	if err := conn.Raw("SELECT file FROM pragma_database_list WHERE name = 'main'").Scan(&path).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	holder, err := conn.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock, err := holder.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Close()
+	if _, err := lock.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		t.Fatal(err)
+	}
+	defer lock.ExecContext(ctx, "ROLLBACK")
+
+	other, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(0)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	_, err = other.ExecContext(ctx, "INSERT INTO users (email, password_hash) VALUES ('busy@example.com', 'hash')")
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code()&0xff != 5 {
+		t.Fatalf("second writer got %v, want SQLITE_BUSY", err)
+	}
+	return err
+}
@@ -0,0 +1,13 @@
+package userService
+
+import "pet1/internal/apperr"
+
+var (
+	// ErrUserNotFound - пользователя с таким ID или email нет
+	ErrUserNotFound = apperr.NotFound("user not found")
+	// ErrEmailTaken - email уже занят другим пользователем
+	ErrEmailTaken = apperr.Conflict("email already taken")
+	// ErrInvalidCredentials возвращается, если email или пароль не подошли.
+	// Намеренно не уточняем, что именно не так
+	ErrInvalidCredentials = apperr.Unauthenticated("invalid credentials")
+)
@@ -0,0 +1,86 @@
+package userService
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"pet1/internal/pagination"
+)
+
+// SortByCreatedAt - сортировка пользователей по времени регистрации, помимо id
+const SortByCreatedAt = "created_at"
+
+// UserFilter - условия отбора пользователей. Нулевые значения означают «не фильтровать»
+type UserFilter struct {
+	// ID оставляет в выборке только этого пользователя: так список видят те,
+	// кому разрешено читать только себя
+	ID uint
+	// EmailPrefix и EmailContains ищут без учёта регистра
+	EmailPrefix   string
+	EmailContains string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// ListParams - запрос страницы пользователей в том виде, в каком он пришёл от клиента
+type ListParams struct {
+	UserFilter
+	pagination.Params
+}
+
+// UserQuery - запрос страницы к репозиторию
+type UserQuery struct {
+	UserFilter
+	pagination.Query
+}
+
+// ListUsers возвращает страницу пользователей. Кого показывать вызывающему,
+// решает хендлер через UserFilter.ID
+func (s *UserService) ListUsers(ctx context.Context, params ListParams) (pagination.Page[User], error) {
+	query, err := pagination.NewQuery(params.Params, pagination.SortField{Name: SortByCreatedAt})
+	if err != nil {
+		return pagination.Page[User]{}, err
+	}
+
+	users, err := s.repo.ListUsers(ctx, UserQuery{UserFilter: params.UserFilter, Query: query})
+	if err != nil {
+		return pagination.Page[User]{}, err
+	}
+	page := pagination.NewPage(users, query, func(user User) pagination.Position {
+		return positionOf(user, query.SortBy)
+	})
+
+	if params.Count {
+		total, err := s.repo.CountUsers(ctx, params.UserFilter)
+		if err != nil {
+			return pagination.Page[User]{}, err
+		}
+		page.Total = &total
+	}
+	return page, nil
+}
+
+// UserExists сообщает, есть ли неудалённый пользователь с таким ID
+func (s *UserService) UserExists(ctx context.Context, id uint) (bool, error) {
+	return s.repo.UserExists(ctx, id)
+}
+
+// match проверяет пользователя на соответствие фильтру. Нужен хранилищам в памяти,
+// база делает то же самое условиями WHERE
+func (f UserFilter) match(user User) bool {
+	email := strings.ToLower(user.Email)
+	return (f.ID == 0 || user.ID == f.ID) &&
+		strings.HasPrefix(email, strings.ToLower(f.EmailPrefix)) &&
+		strings.Contains(email, strings.ToLower(f.EmailContains)) &&
+		(f.CreatedAfter == nil || !user.CreatedAt.Before(*f.CreatedAfter)) &&
+		(f.CreatedBefore == nil || user.CreatedAt.Before(*f.CreatedBefore))
+}
+
+// positionOf возвращает место пользователя в сортировке по полю sortBy
+func positionOf(user User, sortBy string) pagination.Position {
+	if sortBy == SortByCreatedAt {
+		return pagination.Position{Time: user.CreatedAt, ID: user.ID}
+	}
+	return pagination.Position{ID: user.ID}
+}
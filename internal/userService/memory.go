@@ -0,0 +1,241 @@
+package userService
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"pet1/internal/identity"
+	"pet1/internal/pagination"
+	"pet1/internal/taskService"
+
+	"gorm.io/gorm"
+)
+
+// memoryUserRepository - UserRepository в памяти процесса. Повторяет gorm-версию:
+// ID по возрастанию без переиспользования, мягкое удаление, уникальный email
+// (как и индекс в базе, он учитывает и удалённых пользователей)
+type memoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]User
+	lastID uint
+	// tasks нужен, чтобы GetUserByID отдавал пользователя с задачами, как Preload("Tasks")
+	tasks taskService.TaskRepository
+}
+
+func NewMemoryUserRepository(tasks taskService.TaskRepository) *memoryUserRepository {
+	return &memoryUserRepository{users: map[uint]User{}, tasks: tasks}
+}
+
+// Exists сообщает, был ли такой пользователь создан. Мягко удалённые тоже считаются:
+// строка в базе остаётся, и внешний ключ на неё по-прежнему действителен
+func (r *memoryUserRepository) Exists(id uint) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.users[id]
+	return ok
+}
+
+func (r *memoryUserRepository) CreateUser(ctx context.Context, user User) (User, error) {
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.emailTaken(user.Email, 0) {
+		return User{}, ErrEmailTaken
+	}
+
+	r.lastID++
+	now := time.Now()
+	user.ID = r.lastID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	user.DeletedAt = gorm.DeletedAt{}
+	if user.Role == "" {
+		// DEFAULT 'member' из схемы
+		user.Role = identity.RoleMember
+	}
+	// Пароль в открытом виде и задачи в строку пользователя не попадают
+	stored := user
+	stored.Password = ""
+	stored.Tasks = nil
+	r.users[user.ID] = stored
+	return user, nil
+}
+
+func (r *memoryUserRepository) GetAllUsers(ctx context.Context) ([]User, error) {
+	return r.find(ctx, func(User) bool { return true })
+}
+
+func (r *memoryUserRepository) GetUserByID(ctx context.Context, id uint) (User, error) {
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+	// Задачи читаем уже без своей блокировки, см. memoryTaskRepository.CreateTask
+	r.mu.RLock()
+	user, ok := r.get(id)
+	r.mu.RUnlock()
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+
+	tasks, err := r.tasks.GetTasksByUserID(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
+	user.Tasks = tasks
+	return user, nil
+}
+
+func (r *memoryUserRepository) GetUserWithoutTasks(ctx context.Context, id uint) (User, error) {
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.get(id)
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *memoryUserRepository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email && !user.DeletedAt.Valid {
+			return user, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
+
+func (r *memoryUserRepository) UpdateUserByID(ctx context.Context, id uint, user User) (User, error) {
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existingUser, ok := r.get(id)
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	if user.Email != "" {
+		if r.emailTaken(user.Email, id) {
+			return User{}, ErrEmailTaken
+		}
+		existingUser.Email = user.Email
+	}
+	if user.PasswordHash != "" {
+		existingUser.PasswordHash = user.PasswordHash
+	}
+	if user.Role != "" {
+		existingUser.Role = user.Role
+	}
+	existingUser.UpdatedAt = time.Now()
+	r.users[id] = existingUser
+	return existingUser, nil
+}
+
+func (r *memoryUserRepository) DeleteUserByID(ctx context.Context, id uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.get(id)
+	if !ok {
+		return ErrUserNotFound
+	}
+	user.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepository) UserExists(ctx context.Context, id uint) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.get(id)
+	return ok, nil
+}
+
+func (r *memoryUserRepository) ListUsers(ctx context.Context, query UserQuery) ([]User, error) {
+	users, err := r.find(ctx, query.UserFilter.match)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.Slice(users, query.Query, func(user User) pagination.Position {
+		return positionOf(user, query.SortBy)
+	}), nil
+}
+
+func (r *memoryUserRepository) CountUsers(ctx context.Context, filter UserFilter) (int64, error) {
+	users, err := r.find(ctx, filter.match)
+	return int64(len(users)), err
+}
+
+// Snapshot запоминает пользователей для отката транзакции. Счётчик ID не откатывается,
+// как и последовательность в базе
+func (r *memoryUserRepository) Snapshot() func() {
+	r.mu.RLock()
+	saved := maps.Clone(r.users)
+	r.mu.RUnlock()
+	return func() {
+		r.mu.Lock()
+		r.users = saved
+		r.mu.Unlock()
+	}
+}
+
+// get возвращает неудалённого пользователя. Вызывать под блокировкой
+func (r *memoryUserRepository) get(id uint) (User, bool) {
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return User{}, false
+	}
+	return user, true
+}
+
+// emailTaken проверяет уникальность email среди всех пользователей, кроме exceptID.
+// Вызывать под блокировкой
+func (r *memoryUserRepository) emailTaken(email string, exceptID uint) bool {
+	for id, user := range r.users {
+		if id != exceptID && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// find отбирает неудалённых пользователей в порядке ID. Пустой результат - пустой срез, как у gorm
+func (r *memoryUserRepository) find(ctx context.Context, match func(User) bool) ([]User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := []User{}
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && match(user) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
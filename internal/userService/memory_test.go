@@ -0,0 +1,19 @@
+package userService_test
+
+import (
+	"testing"
+
+	"pet1/internal/taskService"
+	"pet1/internal/userService"
+	"pet1/internal/userService/repositorytest"
+)
+
+func TestMemoryUserRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Harness {
+		// Связаны так же, как в cmd/app при DB_DRIVER=memory
+		tasks := taskService.NewMemoryTaskRepository()
+		users := userService.NewMemoryUserRepository(tasks)
+		tasks.SetUserLookup(users.Exists)
+		return repositorytest.Harness{Repo: users, Tasks: tasks}
+	})
+}
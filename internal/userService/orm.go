@@ -1,6 +1,7 @@
 package userService
 
 import (
+	"pet1/internal/identity"
 	"pet1/internal/taskService"
 
 	"gorm.io/gorm"
@@ -8,14 +9,11 @@ import (
 
 type User struct {
 	gorm.Model
-	Email    string             `json:"email"`
-	Password string             `json:"password"`
-	Tasks    []taskService.Task `json:"tasks" gorm:"foreignKey:UserID"`
-}
-
-type Task struct {
-	gorm.Model
-	Task   string `json:"task"`
-	IsDone bool   `json:"is_done"`
-	UserID uint   `json:"user_id"`
+	Email string `json:"email"`
+	// Password - пароль в открытом виде, приходит только на вход и в БД не пишется
+	Password string `json:"-" gorm:"-"`
+	// PasswordHash - хеш пароля, который и хранится в БД
+	PasswordHash string             `json:"-"`
+	Role         identity.Role      `json:"role" gorm:"default:member"`
+	Tasks        []taskService.Task `json:"tasks" gorm:"foreignKey:UserID"`
 }
@@ -1,17 +1,31 @@
 package userService
 
 import (
+	"context"
 	"errors"
+	"pet1/internal/pagination"
+	"pet1/internal/transaction"
+	"strings"
 
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	CreateUser(user User) (User, error)
-	GetAllUsers() ([]User, error)
-	GetUserByID(id uint) (User, error)
-	UpdateUserByID(id uint, user User) (User, error)
-	DeleteUserByID(id uint) error
+	CreateUser(ctx context.Context, user User) (User, error)
+	GetAllUsers(ctx context.Context) ([]User, error)
+	// GetUserByID - пользователь вместе со всеми его задачами
+	GetUserByID(ctx context.Context, id uint) (User, error)
+	// GetUserWithoutTasks - то же без задач, когда нужны только поля пользователя
+	GetUserWithoutTasks(ctx context.Context, id uint) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	UpdateUserByID(ctx context.Context, id uint, user User) (User, error)
+	DeleteUserByID(ctx context.Context, id uint) error
+	// UserExists - есть ли неудалённый пользователь с таким ID
+	UserExists(ctx context.Context, id uint) (bool, error)
+	// ListUsers - страница пользователей по фильтру, в порядке обхода (см. pagination.Query)
+	ListUsers(ctx context.Context, query UserQuery) ([]User, error)
+	// CountUsers - сколько всего пользователей под фильтром
+	CountUsers(ctx context.Context, filter UserFilter) (int64, error)
 }
 
 type userRepository struct {
@@ -22,38 +36,66 @@ func NewUserRepository(db *gorm.DB) *userRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) CreateUser(user User) (User, error) {
-	result := r.db.Create(&user)
+func (r *userRepository) CreateUser(ctx context.Context, user User) (User, error) {
+	result := transaction.Conn(ctx, r.db).Create(&user)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return User{}, ErrEmailTaken
+		}
 		return User{}, result.Error
 	}
 	return user, nil
 }
 
-func (r *userRepository) GetAllUsers() ([]User, error) {
+func (r *userRepository) GetAllUsers(ctx context.Context) ([]User, error) {
 	var users []User
-	err := r.db.Find(&users).Error
+	err := transaction.Conn(ctx, r.db).Find(&users).Error
 	return users, err
 }
 
-func (r *userRepository) GetUserByID(id uint) (User, error) {
+func (r *userRepository) GetUserByID(ctx context.Context, id uint) (User, error) {
+	var user User
+	result := transaction.Conn(ctx, r.db).Preload("Tasks").First(&user, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return User{}, ErrUserNotFound
+		}
+		return User{}, result.Error
+	}
+	return user, nil
+}
+
+// GetUserWithoutTasks читает одну строку users, без Preload("Tasks")
+func (r *userRepository) GetUserWithoutTasks(ctx context.Context, id uint) (User, error) {
 	var user User
-	result := r.db.Preload("Tasks").First(&user, id)
+	result := transaction.Conn(ctx, r.db).First(&user, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return User{}, errors.New("user not found")
+			return User{}, ErrUserNotFound
 		}
 		return User{}, result.Error
 	}
 	return user, nil
 }
 
-func (r *userRepository) UpdateUserByID(id uint, user User) (User, error) {
+func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	var user User
+	result := transaction.Conn(ctx, r.db).Where("email = ?", email).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return User{}, ErrUserNotFound
+		}
+		return User{}, result.Error
+	}
+	return user, nil
+}
+
+func (r *userRepository) UpdateUserByID(ctx context.Context, id uint, user User) (User, error) {
 	var existingUser User
-	result := r.db.First(&existingUser, id)
+	result := transaction.Conn(ctx, r.db).First(&existingUser, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return User{}, errors.New("user not found")
+			return User{}, ErrUserNotFound
 		}
 		return User{}, result.Error
 	}
@@ -61,36 +103,83 @@ func (r *userRepository) UpdateUserByID(id uint, user User) (User, error) {
 	if user.Email != "" {
 		existingUser.Email = user.Email
 	}
-	if user.Password != "" {
-		existingUser.Password = user.Password
+	if user.PasswordHash != "" {
+		existingUser.PasswordHash = user.PasswordHash
+	}
+	if user.Role != "" {
+		existingUser.Role = user.Role
 	}
 
-	saveResult := r.db.Save(&existingUser)
+	saveResult := transaction.Conn(ctx, r.db).Save(&existingUser)
 	if saveResult.Error != nil {
+		if errors.Is(saveResult.Error, gorm.ErrDuplicatedKey) {
+			return User{}, ErrEmailTaken
+		}
 		return User{}, saveResult.Error
 	}
 
 	return existingUser, nil
 }
 
-func (r *userRepository) DeleteUserByID(id uint) error {
+func (r *userRepository) DeleteUserByID(ctx context.Context, id uint) error {
 	var user User
-	result := r.db.First(&user, id)
+	result := transaction.Conn(ctx, r.db).First(&user, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return errors.New("user not found")
+			return ErrUserNotFound
 		}
 		return result.Error
 	}
 
-	deleteResult := r.db.Delete(&user)
+	deleteResult := transaction.Conn(ctx, r.db).Delete(&user)
 	if deleteResult.Error != nil {
 		return deleteResult.Error
 	}
 
 	if deleteResult.RowsAffected == 0 {
-		return errors.New("no user was deleted")
+		return ErrUserNotFound
 	}
 
 	return nil
 }
+
+func (r *userRepository) UserExists(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	err := transaction.Conn(ctx, r.db).Model(&User{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *userRepository) ListUsers(ctx context.Context, query UserQuery) ([]User, error) {
+	users := []User{}
+	err := pagination.Apply(r.filtered(ctx, query.UserFilter), query.Query).Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) CountUsers(ctx context.Context, filter UserFilter) (int64, error) {
+	var total int64
+	err := r.filtered(ctx, filter).Count(&total).Error
+	return total, err
+}
+
+// filtered - запрос к пользователям с условиями фильтра, общий для ListUsers и CountUsers.
+// LOWER(...) LIKE вместо ILIKE, потому что ILIKE нет в SQLite. Время - в UTC,
+// так же, как оно записано: SQLite сравнивает его как строки
+func (r *userRepository) filtered(ctx context.Context, filter UserFilter) *gorm.DB {
+	tx := transaction.Conn(ctx, r.db).Model(&User{})
+	if filter.ID != 0 {
+		tx = tx.Where("id = ?", filter.ID)
+	}
+	if filter.EmailPrefix != "" {
+		tx = tx.Where(`LOWER(email) LIKE ? ESCAPE '\'`, strings.ToLower(pagination.EscapeLike(filter.EmailPrefix))+"%")
+	}
+	if filter.EmailContains != "" {
+		tx = tx.Where(`LOWER(email) LIKE ? ESCAPE '\'`, "%"+strings.ToLower(pagination.EscapeLike(filter.EmailContains))+"%")
+	}
+	if filter.CreatedAfter != nil {
+		tx = tx.Where("created_at >= ?", filter.CreatedAfter.UTC())
+	}
+	if filter.CreatedBefore != nil {
+		tx = tx.Where("created_at < ?", filter.CreatedBefore.UTC())
+	}
+	return tx
+}
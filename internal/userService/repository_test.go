@@ -0,0 +1,20 @@
+package userService_test
+
+import (
+	"testing"
+
+	"pet1/internal/db/dbtest"
+	"pet1/internal/taskService"
+	"pet1/internal/userService"
+	"pet1/internal/userService/repositorytest"
+)
+
+func TestUserRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Harness {
+		conn := dbtest.SQLite(t)
+		return repositorytest.Harness{
+			Repo:  userService.NewUserRepository(conn),
+			Tasks: taskService.NewTaskRepository(conn),
+		}
+	})
+}
@@ -0,0 +1,301 @@
+// Package repositorytest - общий набор тестов для реализаций userService.UserRepository,
+// по тому же образцу, что и taskService/repositorytest
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"pet1/internal/identity"
+	"pet1/internal/taskService"
+	"pet1/internal/userService"
+)
+
+// Harness - репозиторий под проверкой и его окружение
+type Harness struct {
+	Repo userService.UserRepository
+	// Tasks - репозиторий задач, из которого Repo читает задачи пользователя
+	Tasks taskService.TaskRepository
+}
+
+// Run прогоняет набор на репозиториях из setup. setup вызывается на каждый тест
+// и должен отдавать пустые репозитории
+func Run(t *testing.T, setup func(t *testing.T) Harness) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, h Harness)
+	}{
+		{"CreateAssignsIncreasingIDs", testCreateAssignsIncreasingIDs},
+		{"EmailIsUnique", testEmailIsUnique},
+		{"NotFound", testNotFound},
+		{"Update", testUpdate},
+		{"SoftDelete", testSoftDelete},
+		{"GetUserByIDReadsTasks", testGetUserByIDReadsTasks},
+		{"ContextDone", testContextDone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, setup(t))
+		})
+	}
+}
+
+func testCreateAssignsIncreasingIDs(t *testing.T, h Harness) {
+	ctx := context.Background()
+	first := create(t, h, "first@example.com")
+	second := create(t, h, "second@example.com")
+	if first.ID == 0 || second.ID <= first.ID {
+		t.Fatalf("IDs = %d, %d, want increasing and non-zero", first.ID, second.ID)
+	}
+	// Роль по умолчанию ставит схема
+	if first.Role != identity.RoleMember || first.CreatedAt.IsZero() {
+		t.Errorf("CreateUser = %+v, want member with created_at", first)
+	}
+
+	// ID удалённого пользователя не выдаётся повторно
+	if err := h.Repo.DeleteUserByID(ctx, second.ID); err != nil {
+		t.Fatal(err)
+	}
+	third := create(t, h, "third@example.com")
+	if third.ID <= second.ID {
+		t.Errorf("ID after delete = %d, want greater than %d", third.ID, second.ID)
+	}
+
+	got, err := h.Repo.GetUserByEmail(ctx, "first@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != first.ID || got.PasswordHash != "hash" {
+		t.Errorf("GetUserByEmail = %+v, want the created user", got)
+	}
+}
+
+func testEmailIsUnique(t *testing.T, h Harness) {
+	ctx := context.Background()
+	taken := create(t, h, "taken@example.com")
+	other := create(t, h, "other@example.com")
+
+	if _, err := h.Repo.CreateUser(ctx, userService.User{Email: "taken@example.com", PasswordHash: "hash"}); !errors.Is(err, userService.ErrEmailTaken) {
+		t.Errorf("CreateUser with taken email: err = %v, want ErrEmailTaken", err)
+	}
+	if _, err := h.Repo.UpdateUserByID(ctx, other.ID, userService.User{Email: "taken@example.com"}); !errors.Is(err, userService.ErrEmailTaken) {
+		t.Errorf("UpdateUserByID to taken email: err = %v, want ErrEmailTaken", err)
+	}
+	// Свой же email - не конфликт
+	if _, err := h.Repo.UpdateUserByID(ctx, taken.ID, userService.User{Email: "taken@example.com"}); err != nil {
+		t.Errorf("UpdateUserByID to own email: %v", err)
+	}
+
+	// Уникальный индекс учитывает и удалённых пользователей
+	if err := h.Repo.DeleteUserByID(ctx, taken.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Repo.CreateUser(ctx, userService.User{Email: "taken@example.com", PasswordHash: "hash"}); !errors.Is(err, userService.ErrEmailTaken) {
+		t.Errorf("CreateUser with email of deleted user: err = %v, want ErrEmailTaken", err)
+	}
+}
+
+func testNotFound(t *testing.T, h Harness) {
+	ctx := context.Background()
+	const missing = 4242
+
+	if _, err := h.Repo.GetUserByID(ctx, missing); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("GetUserByID: err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := h.Repo.GetUserWithoutTasks(ctx, missing); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("GetUserWithoutTasks: err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := h.Repo.GetUserByEmail(ctx, "nobody@example.com"); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("GetUserByEmail: err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := h.Repo.UpdateUserByID(ctx, missing, userService.User{Email: "x@example.com"}); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("UpdateUserByID: err = %v, want ErrUserNotFound", err)
+	}
+	if err := h.Repo.DeleteUserByID(ctx, missing); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("DeleteUserByID: err = %v, want ErrUserNotFound", err)
+	}
+	if exists, err := h.Repo.UserExists(ctx, missing); err != nil || exists {
+		t.Errorf("UserExists = %t, %v, want false", exists, err)
+	}
+}
+
+func testUpdate(t *testing.T, h Harness) {
+	ctx := context.Background()
+	user := create(t, h, "before@example.com")
+
+	updated, err := h.Repo.UpdateUserByID(ctx, user.ID, userService.User{Email: "after@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Пустые поля означают «не менять»
+	if updated.Email != "after@example.com" || updated.PasswordHash != "hash" || updated.Role != identity.RoleMember {
+		t.Errorf("UpdateUserByID = %+v", updated)
+	}
+	if _, err := h.Repo.UpdateUserByID(ctx, user.ID, userService.User{PasswordHash: "new hash", Role: identity.RoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := h.Repo.GetUserWithoutTasks(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Email != "after@example.com" || got.PasswordHash != "new hash" || got.Role != identity.RoleAdmin {
+		t.Errorf("GetUserWithoutTasks after update = %+v", got)
+	}
+	if _, err := h.Repo.GetUserByEmail(ctx, "before@example.com"); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("old email still finds the user: err = %v", err)
+	}
+}
+
+func testSoftDelete(t *testing.T, h Harness) {
+	ctx := context.Background()
+	kept := create(t, h, "kept@example.com")
+	deleted := create(t, h, "deleted@example.com")
+
+	if err := h.Repo.DeleteUserByID(ctx, deleted.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Repo.DeleteUserByID(ctx, deleted.ID); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("second DeleteUserByID: err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := h.Repo.GetUserByID(ctx, deleted.ID); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("GetUserByID of deleted user: err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := h.Repo.GetUserByEmail(ctx, "deleted@example.com"); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("GetUserByEmail of deleted user: err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := h.Repo.UpdateUserByID(ctx, deleted.ID, userService.User{Role: identity.RoleAdmin}); !errors.Is(err, userService.ErrUserNotFound) {
+		t.Errorf("UpdateUserByID of deleted user: err = %v, want ErrUserNotFound", err)
+	}
+	if exists, err := h.Repo.UserExists(ctx, deleted.ID); err != nil || exists {
+		t.Errorf("UserExists of deleted user = %t, %v, want false", exists, err)
+	}
+
+	all, err := h.Repo.GetAllUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(all); !slices.Equal(got, []uint{kept.ID}) {
+		t.Errorf("GetAllUsers IDs = %v, want [%d]", got, kept.ID)
+	}
+	if total, err := h.Repo.CountUsers(ctx, userService.UserFilter{}); err != nil || total != 1 {
+		t.Errorf("CountUsers = %d, %v, want 1", total, err)
+	}
+}
+
+func testGetUserByIDReadsTasks(t *testing.T, h Harness) {
+	ctx := context.Background()
+	owner := create(t, h, "owner@example.com")
+	other := create(t, h, "other@example.com")
+	for _, task := range []taskService.Task{
+		{Task: "first", UserID: owner.ID},
+		{Task: "second", UserID: owner.ID},
+		{Task: "not mine", UserID: other.ID},
+	} {
+		if _, err := h.Tasks.CreateTask(ctx, task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := h.Repo.GetUserByID(ctx, owner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, task := range got.Tasks {
+		names = append(names, task.Task)
+	}
+	if !slices.Equal(names, []string{"first", "second"}) {
+		t.Errorf("GetUserByID tasks = %v, want [first second]", names)
+	}
+
+	light, err := h.Repo.GetUserWithoutTasks(ctx, owner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if light.ID != owner.ID || len(light.Tasks) != 0 {
+		t.Errorf("GetUserWithoutTasks = %+v, want the user without tasks", light)
+	}
+}
+
+func testContextDone(t *testing.T, h Harness) {
+	user := create(t, h, "untouched@example.com")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"CreateUser", func(ctx context.Context) error {
+			_, err := h.Repo.CreateUser(ctx, userService.User{Email: "new@example.com", PasswordHash: "hash"})
+			return err
+		}},
+		{"GetUserByID", func(ctx context.Context) error {
+			_, err := h.Repo.GetUserByID(ctx, user.ID)
+			return err
+		}},
+		{"GetUserByEmail", func(ctx context.Context) error {
+			_, err := h.Repo.GetUserByEmail(ctx, user.Email)
+			return err
+		}},
+		{"UpdateUserByID", func(ctx context.Context) error {
+			_, err := h.Repo.UpdateUserByID(ctx, user.ID, userService.User{Email: "changed@example.com"})
+			return err
+		}},
+		{"DeleteUserByID", func(ctx context.Context) error {
+			return h.Repo.DeleteUserByID(ctx, user.ID)
+		}},
+		{"CountUsers", func(ctx context.Context) error {
+			_, err := h.Repo.CountUsers(ctx, userService.UserFilter{})
+			return err
+		}},
+	}
+	for _, done := range []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"cancelled", cancelled, context.Canceled},
+		{"expired", expired, context.DeadlineExceeded},
+	} {
+		for _, c := range calls {
+			if err := c.call(done.ctx); !errors.Is(err, done.want) {
+				t.Errorf("%s with %s context: err = %v, want %v", c.name, done.name, err, done.want)
+			}
+		}
+	}
+
+	// Ни один из прерванных вызовов ничего не поменял
+	all, err := h.Repo.GetAllUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].Email != "untouched@example.com" {
+		t.Errorf("users after aborted calls = %+v, want only the untouched one", all)
+	}
+}
+
+// create создаёт пользователя или останавливает тест
+func create(t *testing.T, h Harness, email string) userService.User {
+	t.Helper()
+	user, err := h.Repo.CreateUser(context.Background(), userService.User{Email: email, PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("CreateUser(%q): %v", email, err)
+	}
+	return user
+}
+
+func ids(users []userService.User) []uint {
+	result := make([]uint, 0, len(users))
+	for _, user := range users {
+		result = append(result, user.ID)
+	}
+	return result
+}
@@ -1,42 +1,198 @@
 package userService
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/mail"
+
+	"pet1/internal/apperr"
+	"pet1/internal/identity"
+	"pet1/internal/password"
 	"pet1/internal/taskService"
+	"pet1/internal/transaction"
 )
 
+// Sessions - входы пользователей. Их выдаёт authService, который сам зависит
+// от пользователей, поэтому подключается он после создания сервиса, через SetSessions
+type Sessions interface {
+	RevokeUserSessions(ctx context.Context, userID uint) error
+}
+
 type UserService struct {
 	repo UserRepository
+	// tasks и tx нужны операциям, которые меняют и пользователя, и его задачи разом
+	tasks  taskService.TaskRepository
+	tx     transaction.Manager
+	hasher *password.Hasher
+	// dummyHash нужен, чтобы проверка несуществующего email
+	// занимала столько же времени, сколько и существующего
+	dummyHash string
+	// rehashOnLogin - пересчитывать ли устаревшие хеши при входе
+	rehashOnLogin bool
+	// sessions отзываются вместе с удалённым пользователем. Без них токены
+	// удалённого отклонит сам Refresh
+	sessions Sessions
+}
+
+func NewService(repo UserRepository, tasks taskService.TaskRepository, tx transaction.Manager, hasher *password.Hasher, rehashOnLogin bool) *UserService {
+	dummyHash, err := hasher.Hash("dummy-password")
+	if err != nil {
+		slog.Error("failed to prepare dummy password hash", "error", err)
+	}
+	return &UserService{repo: repo, tasks: tasks, tx: tx, hasher: hasher, dummyHash: dummyHash, rehashOnLogin: rehashOnLogin}
 }
 
-func NewService(repo UserRepository) *UserService {
-	return &UserService{repo: repo}
+// SetSessions подключает отзыв входов при удалении пользователя
+func (s *UserService) SetSessions(sessions Sessions) {
+	s.sessions = sessions
 }
 
-// CreateUser создает нового пользователя
-func (s *UserService) CreateUser(user User) (User, error) {
-	return s.repo.CreateUser(user)
+// CreateUser создает нового пользователя, пароль сохраняется только в виде хеша
+func (s *UserService) CreateUser(ctx context.Context, user User) (User, error) {
+	var invalid apperr.ValidationError
+	if user.Email == "" {
+		invalid.Fields = append(invalid.Fields, apperr.FieldError{Field: "email", Reason: "must not be empty"})
+	}
+	if user.Password == "" {
+		invalid.Fields = append(invalid.Fields, apperr.FieldError{Field: "password", Reason: "must not be empty"})
+	}
+	if len(invalid.Fields) > 0 {
+		return User{}, &invalid
+	}
+	if err := validateEmail(user.Email); err != nil {
+		return User{}, err
+	}
+
+	hash, err := s.hash(user.Password)
+	if err != nil {
+		return User{}, err
+	}
+	user.Password = ""
+	user.PasswordHash = hash
+	if user.Role == "" {
+		user.Role = identity.RoleMember
+	}
+	return s.repo.CreateUser(ctx, user)
+}
+
+// EnsureAdmin создаёт админа с указанным email, если такого пользователя ещё нет.
+// Без этого на пустой базе некому создать первого пользователя
+func (s *UserService) EnsureAdmin(ctx context.Context, email, plain string) error {
+	_, err := s.repo.GetUserByEmail(ctx, email)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrUserNotFound) {
+		return err
+	}
+	_, err = s.CreateUser(ctx, User{Email: email, Password: plain, Role: identity.RoleAdmin})
+	return err
 }
 
-// GetAllUsers возвращает всех пользователей
-func (s *UserService) GetAllUsers() ([]User, error) {
-	return s.repo.GetAllUsers()
+// GetUserByID возвращает пользователя по ID без его задач: вызывающим, например
+// обновлению токенов, нужны только поля самого пользователя
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (User, error) {
+	return s.repo.GetUserWithoutTasks(ctx, id)
 }
 
 // UpdateUserByID обновляет пользователя по ID
-func (s *UserService) UpdateUserByID(id uint, user User) (User, error) {
-	return s.repo.UpdateUserByID(id, user)
+func (s *UserService) UpdateUserByID(ctx context.Context, id uint, user User) (User, error) {
+	if user.Email != "" {
+		if err := validateEmail(user.Email); err != nil {
+			return User{}, err
+		}
+	}
+	if user.Password != "" {
+		hash, err := s.hash(user.Password)
+		if err != nil {
+			return User{}, err
+		}
+		user.Password = ""
+		user.PasswordHash = hash
+	}
+	return s.repo.UpdateUserByID(ctx, id, user)
 }
 
-// DeleteUserByID удаляет пользователя по ID
-func (s *UserService) DeleteUserByID(id uint) error {
-	return s.repo.DeleteUserByID(id)
+// DeleteUserByID удаляет пользователя по ID вместе с его задачами и входами.
+// Всё в одной транзакции: либо удалится и то и другое, либо ничего.
+// На SERIALIZABLE параллельная запись в те же строки не смешается с удалением:
+// база отменит одну из транзакций, и Do повторит её целиком
+func (s *UserService) DeleteUserByID(ctx context.Context, id uint) error {
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteUserByID(ctx, id); err != nil {
+			return err
+		}
+		if s.sessions != nil {
+			if err := s.sessions.RevokeUserSessions(ctx, id); err != nil {
+				return err
+			}
+		}
+		return s.tasks.DeleteTasksByUserID(ctx, id)
+	}, transaction.WithIsolation(sql.LevelSerializable))
 }
 
 // GetTasksForUser получает все задачи пользователя
-func (s *UserService) GetTasksForUser(userID uint) ([]taskService.Task, error) {
-	user, err := s.repo.GetUserByID(userID)
+func (s *UserService) GetTasksForUser(ctx context.Context, userID uint) ([]taskService.Task, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 	return user.Tasks, nil
 }
+
+// Authenticate проверяет email и пароль. Если хеш был посчитан со старыми
+// параметрами, он прозрачно пересчитывается с текущими
+func (s *UserService) Authenticate(ctx context.Context, email, plain string) (User, error) {
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			// Всё равно считаем хеш, чтобы по времени ответа нельзя было понять, есть ли такой email
+			_, _ = s.hasher.Verify(s.dummyHash, plain)
+			return User{}, ErrInvalidCredentials
+		}
+		return User{}, err
+	}
+
+	needsRehash, err := s.hasher.Verify(user.PasswordHash, plain)
+	if err != nil {
+		if errors.Is(err, password.ErrMismatch) {
+			return User{}, ErrInvalidCredentials
+		}
+		return User{}, err
+	}
+
+	if needsRehash && s.rehashOnLogin {
+		hash, err := s.hasher.Hash(plain)
+		if err == nil {
+			var updated User
+			updated, err = s.repo.UpdateUserByID(ctx, user.ID, User{PasswordHash: hash})
+			if err == nil {
+				user = updated
+			}
+		}
+		if err != nil {
+			// Вход не ломаем: старый хеш всё ещё рабочий, попробуем в следующий раз
+			slog.WarnContext(ctx, "failed to rehash password", "user_id", user.ID, "error", err)
+		}
+	}
+
+	return user, nil
+}
+
+// hash хеширует пароль, превращая слишком длинный пароль в ошибку валидации
+func (s *UserService) hash(plain string) (string, error) {
+	hash, err := s.hasher.Hash(plain)
+	if errors.Is(err, password.ErrTooLong) {
+		return "", apperr.Invalid("password", "must be at most 72 bytes")
+	}
+	return hash, err
+}
+
+func validateEmail(email string) error {
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return apperr.Invalid("email", "must be a valid email address")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package userService_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pet1/internal/db/dbtest"
+	"pet1/internal/password"
+	"pet1/internal/taskService"
+	"pet1/internal/transaction"
+	"pet1/internal/userService"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var errBoom = errors.New("boom")
+
+// failingTasks падает на удалении задач - на середине DeleteUserByID,
+// когда сам пользователь уже удалён
+type failingTasks struct {
+	taskService.TaskRepository
+}
+
+func (failingTasks) DeleteTasksByUserID(context.Context, uint) error {
+	return errBoom
+}
+
+type storage struct {
+	users userService.UserRepository
+	tasks taskService.TaskRepository
+	tx    transaction.Manager
+}
+
+func TestDeleteUserByID(t *testing.T) {
+	setups := map[string]func(t *testing.T) storage{
+		"gorm": func(t *testing.T) storage {
+			conn := dbtest.SQLite(t)
+			return storage{
+				users: userService.NewUserRepository(conn),
+				tasks: taskService.NewTaskRepository(conn),
+				tx:    transaction.NewGormManager(conn, 0),
+			}
+		},
+		"memory": func(t *testing.T) storage {
+			tasks := taskService.NewMemoryTaskRepository()
+			users := userService.NewMemoryUserRepository(tasks)
+			tasks.SetUserLookup(users.Exists)
+			return storage{users: users, tasks: tasks, tx: transaction.NewMemoryManager(users, tasks)}
+		},
+	}
+
+	// NewService считает хеш-заглушку для несуществующих пользователей, так что хешер нужен.
+	// Минимальная стоимость bcrypt - чтобы тест не ждал хеширования
+	cfg := password.DefaultConfig()
+	cfg.BcryptCost = bcrypt.MinCost
+	hasher, err := password.NewHasher(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, setup := range setups {
+		t.Run(name+"/rollback", func(t *testing.T) {
+			s := setup(t)
+			user := seed(t, s)
+
+			service := userService.NewService(s.users, failingTasks{s.tasks}, s.tx, hasher, false)
+			if err := service.DeleteUserByID(context.Background(), user.ID); !errors.Is(err, errBoom) {
+				t.Fatalf("DeleteUserByID = %v, want errBoom", err)
+			}
+
+			// Удаление пользователя откатилось вместе с упавшим удалением задач
+			got, err := s.users.GetUserByID(context.Background(), user.ID)
+			if err != nil {
+				t.Fatalf("user is gone after rollback: %v", err)
+			}
+			if len(got.Tasks) != 2 {
+				t.Errorf("user has %d tasks after rollback, want 2", len(got.Tasks))
+			}
+		})
+
+		t.Run(name+"/commit", func(t *testing.T) {
+			s := setup(t)
+			user := seed(t, s)
+
+			service := userService.NewService(s.users, s.tasks, s.tx, hasher, false)
+			if err := service.DeleteUserByID(context.Background(), user.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.users.GetUserByID(context.Background(), user.ID); !errors.Is(err, userService.ErrUserNotFound) {
+				t.Errorf("GetUserByID after delete: err = %v, want ErrUserNotFound", err)
+			}
+			if tasks, err := s.tasks.GetTasksByUserID(context.Background(), user.ID); err != nil || len(tasks) != 0 {
+				t.Errorf("tasks after delete = %v, %v, want none", tasks, err)
+			}
+		})
+	}
+}
+
+// seed заводит пользователя с двумя задачами
+func seed(t *testing.T, s storage) userService.User {
+	t.Helper()
+	ctx := context.Background()
+	user, err := s.users.CreateUser(ctx, userService.User{Email: "doomed@example.com", PasswordHash: "hash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"first", "second"} {
+		if _, err := s.tasks.CreateTask(ctx, taskService.Task{Task: text, UserID: user.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return user
+}
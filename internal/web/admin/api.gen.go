@@ -0,0 +1,490 @@
+// Package admin provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for LogLevelLevel.
+const (
+	Debug LogLevelLevel = "debug"
+	Error LogLevelLevel = "error"
+	Info  LogLevelLevel = "info"
+	Warn  LogLevelLevel = "warn"
+)
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Label defines model for Label.
+type Label struct {
+	// Color Цвет в виде #rrggbb
+	Color     string     `json:"color"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *uint      `json:"id,omitempty"`
+	Name      string     `json:"name"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// LogLevel defines model for LogLevel.
+type LogLevel struct {
+	Level LogLevelLevel `json:"level"`
+}
+
+// LogLevelLevel defines model for LogLevel.Level.
+type LogLevelLevel string
+
+// Priority defines model for Priority.
+type Priority = int
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Detail Подробности именно этого случая
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Ошибки в отдельных полях запроса
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Путь запроса, на котором произошла ошибка
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP-статус ответа
+	Status int `json:"status"`
+
+	// Title Короткое описание вида ошибки
+	Title string `json:"title"`
+
+	// Type URI, определяющий вид ошибки
+	Type string `json:"type"`
+}
+
+// Task defines model for Task.
+type Task struct {
+	// Blocked Задачу блокирует хотя бы одна несделанная задача
+	Blocked   *bool      `json:"blocked,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// DueAt Срок задачи, если есть
+	DueAt *time.Time `json:"due_at,omitempty"`
+
+	// EstimateMinutes Оценка задачи в минутах, если есть
+	EstimateMinutes *int     `json:"estimate_minutes,omitempty"`
+	Id              *uint    `json:"id,omitempty"`
+	IsDone          bool     `json:"is_done"`
+	Labels          *[]Label `json:"labels,omitempty"`
+
+	// ParentId Родительская задача, если это подзадача
+	ParentId *uint     `json:"parent_id,omitempty"`
+	Priority *Priority `json:"priority,omitempty"`
+
+	// RemindAt Когда напомнить о задаче, если нужно
+	RemindAt  *time.Time `json:"remind_at,omitempty"`
+	Task      string     `json:"task"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UserId    uint       `json:"user_id"`
+}
+
+// TaskNode defines model for TaskNode.
+type TaskNode struct {
+	// Children Подзадачи в порядке ID. В GET /tasks/{id}/subtasks не приходят
+	Children *[]TaskNode  `json:"children,omitempty"`
+	Progress TaskProgress `json:"progress"`
+	Task     Task         `json:"task"`
+}
+
+// TaskProgress defines model for TaskProgress.
+type TaskProgress struct {
+	// Done Сколько из них сделано
+	Done int `json:"done"`
+
+	// Percent Доля сделанных в процентах, округлённая вниз. Без подзадач - 0
+	Percent int `json:"percent"`
+
+	// Total Сколько всего подзадач
+	Total int `json:"total"`
+}
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Problem
+
+// Conflict defines model for Conflict.
+type Conflict = Problem
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Problem
+
+// InternalServerError defines model for InternalServerError.
+type InternalServerError = Problem
+
+// NotFound defines model for NotFound.
+type NotFound = Problem
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Problem
+
+// UnprocessableEntity defines model for UnprocessableEntity.
+type UnprocessableEntity = Problem
+
+// PutAdminLogLevelJSONRequestBody defines body for PutAdminLogLevel for application/json ContentType.
+type PutAdminLogLevelJSONRequestBody = LogLevel
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Узнать текущий уровень логирования
+	// (GET /admin/log-level)
+	GetAdminLogLevel(ctx echo.Context) error
+	// Поменять уровень логирования без перезапуска
+	// (PUT /admin/log-level)
+	PutAdminLogLevel(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetAdminLogLevel converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAdminLogLevel(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAdminLogLevel(ctx)
+	return err
+}
+
+// PutAdminLogLevel converts echo context to params.
+func (w *ServerInterfaceWrapper) PutAdminLogLevel(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutAdminLogLevel(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/admin/log-level", wrapper.GetAdminLogLevel)
+	router.PUT(baseURL+"/admin/log-level", wrapper.PutAdminLogLevel)
+
+}
+
+type BadRequestApplicationProblemPlusJSONResponse Problem
+
+type ConflictApplicationProblemPlusJSONResponse Problem
+
+type ForbiddenApplicationProblemPlusJSONResponse Problem
+
+type InternalServerErrorApplicationProblemPlusJSONResponse Problem
+
+type NotFoundApplicationProblemPlusJSONResponse Problem
+
+type UnauthorizedApplicationProblemPlusJSONResponse Problem
+
+type UnprocessableEntityApplicationProblemPlusJSONResponse Problem
+
+type GetAdminLogLevelRequestObject struct {
+}
+
+type GetAdminLogLevelResponseObject interface {
+	VisitGetAdminLogLevelResponse(w http.ResponseWriter) error
+}
+
+type GetAdminLogLevel200JSONResponse LogLevel
+
+func (response GetAdminLogLevel200JSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminLogLevel400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetAdminLogLevel400ApplicationProblemPlusJSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminLogLevel401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetAdminLogLevel401ApplicationProblemPlusJSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminLogLevel403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetAdminLogLevel403ApplicationProblemPlusJSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminLogLevel404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetAdminLogLevel404ApplicationProblemPlusJSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminLogLevel409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response GetAdminLogLevel409ApplicationProblemPlusJSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminLogLevel422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response GetAdminLogLevel422ApplicationProblemPlusJSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminLogLevel500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetAdminLogLevel500ApplicationProblemPlusJSONResponse) VisitGetAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevelRequestObject struct {
+	Body *PutAdminLogLevelJSONRequestBody
+}
+
+type PutAdminLogLevelResponseObject interface {
+	VisitPutAdminLogLevelResponse(w http.ResponseWriter) error
+}
+
+type PutAdminLogLevel200JSONResponse LogLevel
+
+func (response PutAdminLogLevel200JSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevel400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PutAdminLogLevel400ApplicationProblemPlusJSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevel401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PutAdminLogLevel401ApplicationProblemPlusJSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevel403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PutAdminLogLevel403ApplicationProblemPlusJSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevel404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response PutAdminLogLevel404ApplicationProblemPlusJSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevel409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response PutAdminLogLevel409ApplicationProblemPlusJSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevel422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response PutAdminLogLevel422ApplicationProblemPlusJSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutAdminLogLevel500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PutAdminLogLevel500ApplicationProblemPlusJSONResponse) VisitPutAdminLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Узнать текущий уровень логирования
+	// (GET /admin/log-level)
+	GetAdminLogLevel(ctx context.Context, request GetAdminLogLevelRequestObject) (GetAdminLogLevelResponseObject, error)
+	// Поменять уровень логирования без перезапуска
+	// (PUT /admin/log-level)
+	PutAdminLogLevel(ctx context.Context, request PutAdminLogLevelRequestObject) (PutAdminLogLevelResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetAdminLogLevel operation middleware
+func (sh *strictHandler) GetAdminLogLevel(ctx echo.Context) error {
+	var request GetAdminLogLevelRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminLogLevel(ctx.Request().Context(), request.(GetAdminLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminLogLevel")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetAdminLogLevelResponseObject); ok {
+		return validResponse.VisitGetAdminLogLevelResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutAdminLogLevel operation middleware
+func (sh *strictHandler) PutAdminLogLevel(ctx echo.Context) error {
+	var request PutAdminLogLevelRequestObject
+
+	var body PutAdminLogLevelJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutAdminLogLevel(ctx.Request().Context(), request.(PutAdminLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutAdminLogLevel")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutAdminLogLevelResponseObject); ok {
+		return validResponse.VisitPutAdminLogLevelResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
@@ -1,6 +1,6 @@
 // Package tasks provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
 package tasks
 
 import (
@@ -15,38 +15,394 @@ import (
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for Order.
+const (
+	OrderAsc  Order = "asc"
+	OrderDesc Order = "desc"
+)
+
+// Defines values for GetTasksParamsSort.
+const (
+	GetTasksParamsSortCreatedAt GetTasksParamsSort = "created_at"
+	GetTasksParamsSortId        GetTasksParamsSort = "id"
+	GetTasksParamsSortPriority  GetTasksParamsSort = "priority"
+	GetTasksParamsSortUpdatedAt GetTasksParamsSort = "updated_at"
+)
+
+// Defines values for GetTasksParamsOrder.
+const (
+	GetTasksParamsOrderAsc  GetTasksParamsOrder = "asc"
+	GetTasksParamsOrderDesc GetTasksParamsOrder = "desc"
+)
+
+// Defines values for GetTasksParamsDue.
+const (
+	Overdue GetTasksParamsDue = "overdue"
+	Today   GetTasksParamsDue = "today"
+	Week    GetTasksParamsDue = "week"
+)
+
+// Defines values for GetTasksParamsLabelsMatch.
+const (
+	All GetTasksParamsLabelsMatch = "all"
+	Any GetTasksParamsLabelsMatch = "any"
+)
+
+// Defines values for GetUsersIdTasksParamsSort.
+const (
+	GetUsersIdTasksParamsSortCreatedAt GetUsersIdTasksParamsSort = "created_at"
+	GetUsersIdTasksParamsSortId        GetUsersIdTasksParamsSort = "id"
+	GetUsersIdTasksParamsSortPriority  GetUsersIdTasksParamsSort = "priority"
+	GetUsersIdTasksParamsSortUpdatedAt GetUsersIdTasksParamsSort = "updated_at"
+)
+
+// Defines values for GetUsersIdTasksParamsOrder.
+const (
+	Asc  GetUsersIdTasksParamsOrder = "asc"
+	Desc GetUsersIdTasksParamsOrder = "desc"
+)
+
+// CriticalPath defines model for CriticalPath.
+type CriticalPath struct {
+	// EstimateMinutes Сумма оценок задач цепочки
+	EstimateMinutes int `json:"estimate_minutes"`
+
+	// Tasks Задачи цепочки, от первой к последней
+	Tasks []Task `json:"tasks"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Label defines model for Label.
+type Label struct {
+	// Color Цвет в виде #rrggbb
+	Color     string     `json:"color"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *uint      `json:"id,omitempty"`
+	Name      string     `json:"name"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Priority defines model for Priority.
+type Priority = int
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Detail Подробности именно этого случая
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Ошибки в отдельных полях запроса
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Путь запроса, на котором произошла ошибка
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP-статус ответа
+	Status int `json:"status"`
+
+	// Title Короткое описание вида ошибки
+	Title string `json:"title"`
+
+	// Type URI, определяющий вид ошибки
+	Type string `json:"type"`
+}
+
 // Task defines model for Task.
 type Task struct {
+	// Blocked Задачу блокирует хотя бы одна несделанная задача
+	Blocked   *bool      `json:"blocked,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
-	Id        *uint      `json:"id,omitempty"`
-	IsDone    bool       `json:"is_done"`
+
+	// DueAt Срок задачи, если есть
+	DueAt *time.Time `json:"due_at,omitempty"`
+
+	// EstimateMinutes Оценка задачи в минутах, если есть
+	EstimateMinutes *int     `json:"estimate_minutes,omitempty"`
+	Id              *uint    `json:"id,omitempty"`
+	IsDone          bool     `json:"is_done"`
+	Labels          *[]Label `json:"labels,omitempty"`
+
+	// ParentId Родительская задача, если это подзадача
+	ParentId *uint     `json:"parent_id,omitempty"`
+	Priority *Priority `json:"priority,omitempty"`
+
+	// RemindAt Когда напомнить о задаче, если нужно
+	RemindAt  *time.Time `json:"remind_at,omitempty"`
 	Task      string     `json:"task"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
-	UserId    uint       `json:"user_id,omitempty"`
+	UserId    uint       `json:"user_id"`
+}
+
+// TaskNode defines model for TaskNode.
+type TaskNode struct {
+	// Children Подзадачи в порядке ID. В GET /tasks/{id}/subtasks не приходят
+	Children *[]TaskNode  `json:"children,omitempty"`
+	Progress TaskProgress `json:"progress"`
+	Task     Task         `json:"task"`
+}
+
+// TaskPlan defines model for TaskPlan.
+type TaskPlan struct {
+	CriticalPath *CriticalPath `json:"critical_path,omitempty"`
+
+	// Order Несделанные задачи в порядке выполнения
+	Order []Task `json:"order"`
+}
+
+// TaskProgress defines model for TaskProgress.
+type TaskProgress struct {
+	// Done Сколько из них сделано
+	Done int `json:"done"`
+
+	// Percent Доля сделанных в процентах, округлённая вниз. Без подзадач - 0
+	Percent int `json:"percent"`
+
+	// Total Сколько всего подзадач
+	Total int `json:"total"`
+}
+
+// TaskSearchResult defines model for TaskSearchResult.
+type TaskSearchResult struct {
+	// Headline Фрагмент текста задачи с совпадениями в тегах <mark>
+	Headline string `json:"headline"`
+
+	// Rank Релевантность, больше - лучше. Сравнима только внутри одного ответа
+	Rank float64 `json:"rank"`
+	Task Task    `json:"task"`
 }
 
 // TaskWithoutUserID defines model for TaskWithoutUserID.
 type TaskWithoutUserID struct {
+	// Blocked Задачу блокирует хотя бы одна несделанная задача
+	Blocked   *bool      `json:"blocked,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
-	Id        *uint      `json:"id,omitempty"`
-	IsDone    bool       `json:"is_done"`
+
+	// DueAt Срок задачи, если есть
+	DueAt *time.Time `json:"due_at,omitempty"`
+
+	// EstimateMinutes Оценка задачи в минутах, если есть
+	EstimateMinutes *int     `json:"estimate_minutes,omitempty"`
+	Id              *uint    `json:"id,omitempty"`
+	IsDone          bool     `json:"is_done"`
+	Labels          *[]Label `json:"labels,omitempty"`
+
+	// ParentId Родительская задача, если это подзадача
+	ParentId *uint     `json:"parent_id,omitempty"`
+	Priority *Priority `json:"priority,omitempty"`
+
+	// RemindAt Когда напомнить о задаче, если нужно
+	RemindAt  *time.Time `json:"remind_at,omitempty"`
 	Task      string     `json:"task"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// Count defines model for Count.
+type Count = bool
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// Order defines model for Order.
+type Order string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Problem
+
+// Conflict defines model for Conflict.
+type Conflict = Problem
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Problem
+
+// InternalServerError defines model for InternalServerError.
+type InternalServerError = Problem
+
+// NotFound defines model for NotFound.
+type NotFound = Problem
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Problem
+
+// UnprocessableEntity defines model for UnprocessableEntity.
+type UnprocessableEntity = Problem
+
+// GetTasksParams defines parameters for GetTasks.
+type GetTasksParams struct {
+	// Limit Размер страницы
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из ссылки next или prev
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Поле сортировки. При равенстве значений порядок задаёт id
+	Sort *GetTasksParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Направление сортировки
+	Order  *GetTasksParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+	IsDone *bool                `form:"is_done,omitempty" json:"is_done,omitempty"`
+
+	// UserId Чьи задачи показать. По умолчанию - вызывающего
+	UserId *uint `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// CreatedAfter Созданные не раньше этого момента
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Созданные раньше этого момента
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// UpdatedAfter Изменённые не раньше этого момента
+	UpdatedAfter *time.Time `form:"updated_after,omitempty" json:"updated_after,omitempty"`
+
+	// UpdatedBefore Изменённые раньше этого момента
+	UpdatedBefore *time.Time `form:"updated_before,omitempty" json:"updated_before,omitempty"`
+
+	// Due Быстрый фильтр по сроку: overdue - срок прошёл, а задача не сделана,
+	// today - срок сегодня, week - на этой неделе, с понедельника по воскресенье.
+	// Задачи без срока сюда не попадают
+	Due *GetTasksParamsDue `form:"due,omitempty" json:"due,omitempty"`
+
+	// Tz Часовой пояс вызывающего из базы IANA для границ дня и недели в due, например Europe/Moscow
+	Tz *string `form:"tz,omitempty" json:"tz,omitempty"`
+
+	// Labels ID меток через запятую. Задачи хотя бы с одной из них или, с labels_match=all, со всеми
+	Labels *[]uint `form:"labels,omitempty" json:"labels,omitempty"`
+
+	// LabelsMatch any - нужна любая из меток labels, all - все сразу
+	LabelsMatch *GetTasksParamsLabelsMatch `form:"labels_match,omitempty" json:"labels_match,omitempty"`
+
+	// Blocked true - только задачи, которых держат несделанные блокирующие, false - только свободные
+	Blocked *bool `form:"blocked,omitempty" json:"blocked,omitempty"`
+
+	// Count Считать ли общее число записей для X-Total-Count. На больших выборках лучше выключить
+	Count *Count `form:"count,omitempty" json:"count,omitempty"`
+}
+
+// GetTasksParamsSort defines parameters for GetTasks.
+type GetTasksParamsSort string
+
+// GetTasksParamsOrder defines parameters for GetTasks.
+type GetTasksParamsOrder string
+
+// GetTasksParamsDue defines parameters for GetTasks.
+type GetTasksParamsDue string
+
+// GetTasksParamsLabelsMatch defines parameters for GetTasks.
+type GetTasksParamsLabelsMatch string
+
 // PostTasksJSONBody defines parameters for PostTasks.
 type PostTasksJSONBody struct {
-	IsDone bool   `json:"is_done"`
-	Task   string `json:"task"`
-	UserId uint   `json:"user_id"`
+	// DueAt Срок задачи
+	DueAt *time.Time `json:"due_at,omitempty"`
+
+	// EstimateMinutes Оценка задачи в минутах
+	EstimateMinutes *int `json:"estimate_minutes,omitempty"`
+	IsDone          bool `json:"is_done"`
+
+	// Labels ID меток владельца задачи
+	Labels *[]uint `json:"labels,omitempty"`
+
+	// ParentId Родительская задача того же владельца, если это подзадача
+	ParentId *uint     `json:"parent_id,omitempty"`
+	Priority *Priority `json:"priority,omitempty"`
+
+	// RemindAt Когда напомнить о задаче
+	RemindAt *time.Time `json:"remind_at,omitempty"`
+	Task     string     `json:"task"`
+
+	// UserId Владелец задачи. По умолчанию - вызывающий, другого указать может только админ
+	UserId *uint `json:"user_id,omitempty"`
+}
+
+// GetTasksPlanParams defines parameters for GetTasksPlan.
+type GetTasksPlanParams struct {
+	// UserId Чьи задачи планировать. По умолчанию - вызывающего
+	UserId *uint `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// GetTasksSearchParams defines parameters for GetTasksSearch.
+type GetTasksSearchParams struct {
+	// Q Поисковый запрос
+	Q string `form:"q" json:"q"`
+
+	// Limit Размер страницы
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // PatchTasksIdJSONBody defines parameters for PatchTasksId.
 type PatchTasksIdJSONBody struct {
-	IsDone *bool   `json:"is_done,omitempty"`
-	Task   *string `json:"task,omitempty"`
+	// AddLabels ID меток владельца задачи, которые надо повесить на задачу
+	AddLabels *[]uint `json:"add_labels,omitempty"`
+
+	// ClearDueAt Убрать срок задачи
+	ClearDueAt *bool `json:"clear_due_at,omitempty"`
+
+	// ClearEstimate Убрать оценку задачи
+	ClearEstimate *bool `json:"clear_estimate,omitempty"`
+
+	// ClearParentId Сделать задачу задачей верхнего уровня
+	ClearParentId *bool `json:"clear_parent_id,omitempty"`
+
+	// ClearRemindAt Убрать напоминание
+	ClearRemindAt *bool `json:"clear_remind_at,omitempty"`
+
+	// DueAt Новый срок задачи
+	DueAt *time.Time `json:"due_at,omitempty"`
+
+	// EstimateMinutes Новая оценка задачи в минутах
+	EstimateMinutes *int `json:"estimate_minutes,omitempty"`
+
+	// Force Закрыть задачу, даже если блокирующие её задачи ещё не сделаны
+	Force  *bool `json:"force,omitempty"`
+	IsDone *bool `json:"is_done,omitempty"`
+
+	// ParentId Перенести задачу со всеми подзадачами под другую задачу того же владельца
+	ParentId *uint     `json:"parent_id,omitempty"`
+	Priority *Priority `json:"priority,omitempty"`
+
+	// RemindAt Новое время напоминания. Если оно поменялось, напоминание придёт заново
+	RemindAt *time.Time `json:"remind_at,omitempty"`
+
+	// RemoveLabels ID меток, которые надо снять с задачи
+	RemoveLabels *[]uint `json:"remove_labels,omitempty"`
+	Task         *string `json:"task,omitempty"`
+}
+
+// GetUsersIdTasksParams defines parameters for GetUsersIdTasks.
+type GetUsersIdTasksParams struct {
+	// Limit Размер страницы
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из ссылки next или prev
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Поле сортировки. При равенстве значений порядок задаёт id
+	Sort *GetUsersIdTasksParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Направление сортировки
+	Order  *GetUsersIdTasksParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+	IsDone *bool                       `form:"is_done,omitempty" json:"is_done,omitempty"`
+
+	// Count Считать ли общее число записей для X-Total-Count. На больших выборках лучше выключить
+	Count *Count `form:"count,omitempty" json:"count,omitempty"`
 }
 
+// GetUsersIdTasksParamsSort defines parameters for GetUsersIdTasks.
+type GetUsersIdTasksParamsSort string
+
+// GetUsersIdTasksParamsOrder defines parameters for GetUsersIdTasks.
+type GetUsersIdTasksParamsOrder string
+
 // PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
 type PostTasksJSONRequestBody PostTasksJSONBody
 
@@ -55,21 +411,42 @@ type PatchTasksIdJSONRequestBody PatchTasksIdJSONBody
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// Получить все задачи
+	// Получить задачи постранично
 	// (GET /tasks)
-	GetTasks(ctx echo.Context) error
+	GetTasks(ctx echo.Context, params GetTasksParams) error
 	// Создать новую задачу
 	// (POST /tasks)
 	PostTasks(ctx echo.Context) error
+	// Получить порядок выполнения задач
+	// (GET /tasks/plan)
+	GetTasksPlan(ctx echo.Context, params GetTasksPlanParams) error
+	// Искать по тексту своих задач
+	// (GET /tasks/search)
+	GetTasksSearch(ctx echo.Context, params GetTasksSearchParams) error
 	// Удалить задачу по ID
 	// (DELETE /tasks/{id})
 	DeleteTasksId(ctx echo.Context, id uint) error
 	// Обновить задачу по ID
 	// (PATCH /tasks/{id})
 	PatchTasksId(ctx echo.Context, id uint) error
-	// Получить все задачи пользователя
+	// Получить задачи, которые блокируют задачу
+	// (GET /tasks/{id}/blockers)
+	GetTasksIdBlockers(ctx echo.Context, id uint) error
+	// Убрать зависимость задачи от blocker_id
+	// (DELETE /tasks/{id}/blockers/{blocker_id})
+	DeleteTasksIdBlockersBlockerId(ctx echo.Context, id uint, blockerId uint) error
+	// Отметить, что задача blocker_id блокирует задачу
+	// (PUT /tasks/{id}/blockers/{blocker_id})
+	PutTasksIdBlockersBlockerId(ctx echo.Context, id uint, blockerId uint) error
+	// Получить прямые подзадачи задачи
+	// (GET /tasks/{id}/subtasks)
+	GetTasksIdSubtasks(ctx echo.Context, id uint) error
+	// Получить задачу со всеми подзадачами деревом
+	// (GET /tasks/{id}/tree)
+	GetTasksIdTree(ctx echo.Context, id uint) error
+	// Получить постранично задачи пользователя
 	// (GET /users/{id}/tasks)
-	GetUsersIdTasks(ctx echo.Context, id uint) error
+	GetUsersIdTasks(ctx echo.Context, id uint, params GetUsersIdTasksParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -81,8 +458,124 @@ type ServerInterfaceWrapper struct {
 func (w *ServerInterfaceWrapper) GetTasks(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "is_done" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_done", ctx.QueryParams(), &params.IsDone)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter is_done: %s", err))
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", ctx.QueryParams(), &params.CreatedAfter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_after: %s", err))
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", ctx.QueryParams(), &params.CreatedBefore)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter created_before: %s", err))
+	}
+
+	// ------------- Optional query parameter "updated_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_after", ctx.QueryParams(), &params.UpdatedAfter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter updated_after: %s", err))
+	}
+
+	// ------------- Optional query parameter "updated_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_before", ctx.QueryParams(), &params.UpdatedBefore)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter updated_before: %s", err))
+	}
+
+	// ------------- Optional query parameter "due" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "due", ctx.QueryParams(), &params.Due)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter due: %s", err))
+	}
+
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tz", ctx.QueryParams(), &params.Tz)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tz: %s", err))
+	}
+
+	// ------------- Optional query parameter "labels" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "labels", ctx.QueryParams(), &params.Labels)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter labels: %s", err))
+	}
+
+	// ------------- Optional query parameter "labels_match" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labels_match", ctx.QueryParams(), &params.LabelsMatch)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter labels_match: %s", err))
+	}
+
+	// ------------- Optional query parameter "blocked" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "blocked", ctx.QueryParams(), &params.Blocked)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter blocked: %s", err))
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "count", ctx.QueryParams(), &params.Count)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter count: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetTasks(ctx)
+	err = w.Handler.GetTasks(ctx, params)
 	return err
 }
 
@@ -90,22 +583,73 @@ func (w *ServerInterfaceWrapper) GetTasks(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostTasks(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(BearerAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PostTasks(ctx)
 	return err
 }
 
+// GetTasksPlan converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTasksPlan(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksPlanParams
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTasksPlan(ctx, params)
+	return err
+}
+
+// GetTasksSearch converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTasksSearch(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksSearchParams
+	// ------------- Required query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "q", ctx.QueryParams(), &params.Q)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTasksSearch(ctx, params)
+	return err
+}
+
 // DeleteTasksId converts echo context to params.
 func (w *ServerInterfaceWrapper) DeleteTasksId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id uint
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	ctx.Set(BearerAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.DeleteTasksId(ctx, id)
 	return err
@@ -117,191 +661,1428 @@ func (w *ServerInterfaceWrapper) PatchTasksId(ctx echo.Context) error {
 	// ------------- Path parameter "id" -------------
 	var id uint
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	ctx.Set(BearerAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PatchTasksId(ctx, id)
 	return err
 }
 
-// GetUsersIdTasks converts echo context to params.
-func (w *ServerInterfaceWrapper) GetUsersIdTasks(ctx echo.Context) error {
+// GetTasksIdBlockers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTasksIdBlockers(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTasksIdBlockers(ctx, id)
+	return err
+}
+
+// DeleteTasksIdBlockersBlockerId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteTasksIdBlockersBlockerId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id uint
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
-	}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "blocker_id" -------------
+	var blockerId uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "blocker_id", ctx.Param("blocker_id"), &blockerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter blocker_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteTasksIdBlockersBlockerId(ctx, id, blockerId)
+	return err
+}
+
+// PutTasksIdBlockersBlockerId converts echo context to params.
+func (w *ServerInterfaceWrapper) PutTasksIdBlockersBlockerId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "blocker_id" -------------
+	var blockerId uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "blocker_id", ctx.Param("blocker_id"), &blockerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter blocker_id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutTasksIdBlockersBlockerId(ctx, id, blockerId)
+	return err
+}
+
+// GetTasksIdSubtasks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTasksIdSubtasks(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTasksIdSubtasks(ctx, id)
+	return err
+}
+
+// GetTasksIdTree converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTasksIdTree(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTasksIdTree(ctx, id)
+	return err
+}
+
+// GetUsersIdTasks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersIdTasks(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id uint
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdTasksParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", ctx.QueryParams(), &params.Order)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order: %s", err))
+	}
+
+	// ------------- Optional query parameter "is_done" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_done", ctx.QueryParams(), &params.IsDone)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter is_done: %s", err))
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "count", ctx.QueryParams(), &params.Count)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter count: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersIdTasks(ctx, id, params)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/tasks", wrapper.GetTasks)
+	router.POST(baseURL+"/tasks", wrapper.PostTasks)
+	router.GET(baseURL+"/tasks/plan", wrapper.GetTasksPlan)
+	router.GET(baseURL+"/tasks/search", wrapper.GetTasksSearch)
+	router.DELETE(baseURL+"/tasks/:id", wrapper.DeleteTasksId)
+	router.PATCH(baseURL+"/tasks/:id", wrapper.PatchTasksId)
+	router.GET(baseURL+"/tasks/:id/blockers", wrapper.GetTasksIdBlockers)
+	router.DELETE(baseURL+"/tasks/:id/blockers/:blocker_id", wrapper.DeleteTasksIdBlockersBlockerId)
+	router.PUT(baseURL+"/tasks/:id/blockers/:blocker_id", wrapper.PutTasksIdBlockersBlockerId)
+	router.GET(baseURL+"/tasks/:id/subtasks", wrapper.GetTasksIdSubtasks)
+	router.GET(baseURL+"/tasks/:id/tree", wrapper.GetTasksIdTree)
+	router.GET(baseURL+"/users/:id/tasks", wrapper.GetUsersIdTasks)
+
+}
+
+type BadRequestApplicationProblemPlusJSONResponse Problem
+
+type ConflictApplicationProblemPlusJSONResponse Problem
+
+type ForbiddenApplicationProblemPlusJSONResponse Problem
+
+type InternalServerErrorApplicationProblemPlusJSONResponse Problem
+
+type NotFoundApplicationProblemPlusJSONResponse Problem
+
+type UnauthorizedApplicationProblemPlusJSONResponse Problem
+
+type UnprocessableEntityApplicationProblemPlusJSONResponse Problem
+
+type GetTasksRequestObject struct {
+	Params GetTasksParams
+}
+
+type GetTasksResponseObject interface {
+	VisitGetTasksResponse(w http.ResponseWriter) error
+}
+
+type GetTasks200ResponseHeaders struct {
+	Link        string
+	XTotalCount int64
+}
+
+type GetTasks200JSONResponse struct {
+	Body    []Task
+	Headers GetTasks200ResponseHeaders
+}
+
+func (response GetTasks200JSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTasks400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasks400ApplicationProblemPlusJSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasks401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasks401ApplicationProblemPlusJSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasks403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasks403ApplicationProblemPlusJSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasks404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasks404ApplicationProblemPlusJSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasks409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasks409ApplicationProblemPlusJSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasks422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasks422ApplicationProblemPlusJSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasks500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasks500ApplicationProblemPlusJSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasksRequestObject struct {
+	Body *PostTasksJSONRequestBody
+}
+
+type PostTasksResponseObject interface {
+	VisitPostTasksResponse(w http.ResponseWriter) error
+}
+
+type PostTasks201JSONResponse Task
+
+func (response PostTasks201JSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasks400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PostTasks400ApplicationProblemPlusJSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasks401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PostTasks401ApplicationProblemPlusJSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasks403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PostTasks403ApplicationProblemPlusJSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasks404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response PostTasks404ApplicationProblemPlusJSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasks409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response PostTasks409ApplicationProblemPlusJSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasks422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response PostTasks422ApplicationProblemPlusJSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostTasks500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PostTasks500ApplicationProblemPlusJSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlanRequestObject struct {
+	Params GetTasksPlanParams
+}
+
+type GetTasksPlanResponseObject interface {
+	VisitGetTasksPlanResponse(w http.ResponseWriter) error
+}
+
+type GetTasksPlan200JSONResponse TaskPlan
+
+func (response GetTasksPlan200JSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlan400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksPlan400ApplicationProblemPlusJSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlan401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksPlan401ApplicationProblemPlusJSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlan403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksPlan403ApplicationProblemPlusJSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlan404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksPlan404ApplicationProblemPlusJSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlan409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksPlan409ApplicationProblemPlusJSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlan422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksPlan422ApplicationProblemPlusJSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksPlan500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksPlan500ApplicationProblemPlusJSONResponse) VisitGetTasksPlanResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearchRequestObject struct {
+	Params GetTasksSearchParams
+}
+
+type GetTasksSearchResponseObject interface {
+	VisitGetTasksSearchResponse(w http.ResponseWriter) error
+}
+
+type GetTasksSearch200JSONResponse []TaskSearchResult
+
+func (response GetTasksSearch200JSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearch400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksSearch400ApplicationProblemPlusJSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearch401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksSearch401ApplicationProblemPlusJSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearch403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksSearch403ApplicationProblemPlusJSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearch404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksSearch404ApplicationProblemPlusJSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearch409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksSearch409ApplicationProblemPlusJSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearch422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksSearch422ApplicationProblemPlusJSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksSearch500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksSearch500ApplicationProblemPlusJSONResponse) VisitGetTasksSearchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdRequestObject struct {
+	Id uint `json:"id"`
+}
+
+type DeleteTasksIdResponseObject interface {
+	VisitDeleteTasksIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteTasksId204Response struct {
+}
+
+func (response DeleteTasksId204Response) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteTasksId400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksId400ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksId401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksId401ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksId403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksId403ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksId404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksId404ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksId409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksId409ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksId422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksId422ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksId500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksId500ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksIdRequestObject struct {
+	Id   uint `json:"id"`
+	Body *PatchTasksIdJSONRequestBody
+}
+
+type PatchTasksIdResponseObject interface {
+	VisitPatchTasksIdResponse(w http.ResponseWriter) error
+}
+
+type PatchTasksId200JSONResponse Task
+
+func (response PatchTasksId200JSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksId400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PatchTasksId400ApplicationProblemPlusJSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksId401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PatchTasksId401ApplicationProblemPlusJSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksId403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PatchTasksId403ApplicationProblemPlusJSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksId404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response PatchTasksId404ApplicationProblemPlusJSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksId409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response PatchTasksId409ApplicationProblemPlusJSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksId422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response PatchTasksId422ApplicationProblemPlusJSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchTasksId500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PatchTasksId500ApplicationProblemPlusJSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockersRequestObject struct {
+	Id uint `json:"id"`
+}
+
+type GetTasksIdBlockersResponseObject interface {
+	VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error
+}
+
+type GetTasksIdBlockers200JSONResponse []Task
+
+func (response GetTasksIdBlockers200JSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockers400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdBlockers400ApplicationProblemPlusJSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockers401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdBlockers401ApplicationProblemPlusJSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockers403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdBlockers403ApplicationProblemPlusJSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockers404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdBlockers404ApplicationProblemPlusJSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockers409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdBlockers409ApplicationProblemPlusJSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockers422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdBlockers422ApplicationProblemPlusJSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdBlockers500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdBlockers500ApplicationProblemPlusJSONResponse) VisitGetTasksIdBlockersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdBlockersBlockerIdRequestObject struct {
+	Id        uint `json:"id"`
+	BlockerId uint `json:"blocker_id"`
+}
+
+type DeleteTasksIdBlockersBlockerIdResponseObject interface {
+	VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteTasksIdBlockersBlockerId204Response struct {
+}
+
+func (response DeleteTasksIdBlockersBlockerId204Response) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteTasksIdBlockersBlockerId400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksIdBlockersBlockerId400ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdBlockersBlockerId401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksIdBlockersBlockerId401ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdBlockersBlockerId403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksIdBlockersBlockerId403ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdBlockersBlockerId404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksIdBlockersBlockerId404ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdBlockersBlockerId409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksIdBlockersBlockerId409ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdBlockersBlockerId422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksIdBlockersBlockerId422ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTasksIdBlockersBlockerId500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteTasksIdBlockersBlockerId500ApplicationProblemPlusJSONResponse) VisitDeleteTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTasksIdBlockersBlockerIdRequestObject struct {
+	Id        uint `json:"id"`
+	BlockerId uint `json:"blocker_id"`
+}
+
+type PutTasksIdBlockersBlockerIdResponseObject interface {
+	VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error
+}
+
+type PutTasksIdBlockersBlockerId204Response struct {
+}
+
+func (response PutTasksIdBlockersBlockerId204Response) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PutTasksIdBlockersBlockerId400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PutTasksIdBlockersBlockerId400ApplicationProblemPlusJSONResponse) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTasksIdBlockersBlockerId401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response PutTasksIdBlockersBlockerId401ApplicationProblemPlusJSONResponse) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTasksIdBlockersBlockerId403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response PutTasksIdBlockersBlockerId403ApplicationProblemPlusJSONResponse) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTasksIdBlockersBlockerId404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response PutTasksIdBlockersBlockerId404ApplicationProblemPlusJSONResponse) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTasksIdBlockersBlockerId409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response PutTasksIdBlockersBlockerId409ApplicationProblemPlusJSONResponse) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTasksIdBlockersBlockerId422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response PutTasksIdBlockersBlockerId422ApplicationProblemPlusJSONResponse) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTasksIdBlockersBlockerId500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response PutTasksIdBlockersBlockerId500ApplicationProblemPlusJSONResponse) VisitPutTasksIdBlockersBlockerIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdSubtasksRequestObject struct {
+	Id uint `json:"id"`
+}
+
+type GetTasksIdSubtasksResponseObject interface {
+	VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error
+}
+
+type GetTasksIdSubtasks200JSONResponse []TaskNode
+
+func (response GetTasksIdSubtasks200JSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdSubtasks400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdSubtasks400ApplicationProblemPlusJSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdSubtasks401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdSubtasks401ApplicationProblemPlusJSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdSubtasks403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdSubtasks403ApplicationProblemPlusJSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTasksIdSubtasks404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetTasksIdSubtasks404ApplicationProblemPlusJSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetUsersIdTasks(ctx, id)
-	return err
+type GetTasksIdSubtasks409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+func (response GetTasksIdSubtasks409ApplicationProblemPlusJSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+type GetTasksIdSubtasks422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+func (response GetTasksIdSubtasks422ApplicationProblemPlusJSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	router.GET(baseURL+"/tasks", wrapper.GetTasks)
-	router.POST(baseURL+"/tasks", wrapper.PostTasks)
-	router.DELETE(baseURL+"/tasks/:id", wrapper.DeleteTasksId)
-	router.PATCH(baseURL+"/tasks/:id", wrapper.PatchTasksId)
-	router.GET(baseURL+"/users/:id/tasks", wrapper.GetUsersIdTasks)
+type GetTasksIdSubtasks500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
 
+func (response GetTasksIdSubtasks500ApplicationProblemPlusJSONResponse) VisitGetTasksIdSubtasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTasksRequestObject struct {
+type GetTasksIdTreeRequestObject struct {
+	Id uint `json:"id"`
 }
 
-type GetTasksResponseObject interface {
-	VisitGetTasksResponse(w http.ResponseWriter) error
+type GetTasksIdTreeResponseObject interface {
+	VisitGetTasksIdTreeResponse(w http.ResponseWriter) error
 }
 
-type GetTasks200JSONResponse []Task
+type GetTasksIdTree200JSONResponse TaskNode
 
-func (response GetTasks200JSONResponse) VisitGetTasksResponse(w http.ResponseWriter) error {
+func (response GetTasksIdTree200JSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostTasksRequestObject struct {
-	Body *PostTasksJSONRequestBody
-}
-
-type PostTasksResponseObject interface {
-	VisitPostTasksResponse(w http.ResponseWriter) error
+type GetTasksIdTree400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
 }
 
-type PostTasks201JSONResponse Task
-
-func (response PostTasks201JSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+func (response GetTasksIdTree400ApplicationProblemPlusJSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteTasksIdRequestObject struct {
-	Id uint `json:"id"`
+type GetTasksIdTree401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
 }
 
-type DeleteTasksIdResponseObject interface {
-	VisitDeleteTasksIdResponse(w http.ResponseWriter) error
+func (response GetTasksIdTree401ApplicationProblemPlusJSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteTasksId204Response struct {
+type GetTasksIdTree403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
 }
 
-func (response DeleteTasksId204Response) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+func (response GetTasksIdTree403ApplicationProblemPlusJSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteTasksId404Response struct {
+type GetTasksIdTree404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
 }
 
-func (response DeleteTasksId404Response) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+func (response GetTasksIdTree404ApplicationProblemPlusJSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchTasksIdRequestObject struct {
-	Id   uint `json:"id"`
-	Body *PatchTasksIdJSONRequestBody
+type GetTasksIdTree409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
 }
 
-type PatchTasksIdResponseObject interface {
-	VisitPatchTasksIdResponse(w http.ResponseWriter) error
+func (response GetTasksIdTree409ApplicationProblemPlusJSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchTasksId200JSONResponse Task
+type GetTasksIdTree422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
 
-func (response PatchTasksId200JSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (response GetTasksIdTree422ApplicationProblemPlusJSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchTasksId404Response struct {
+type GetTasksIdTree500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
 }
 
-func (response PatchTasksId404Response) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
-	return nil
+func (response GetTasksIdTree500ApplicationProblemPlusJSONResponse) VisitGetTasksIdTreeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 type GetUsersIdTasksRequestObject struct {
-	Id uint `json:"id"`
+	Id     uint `json:"id"`
+	Params GetUsersIdTasksParams
 }
 
 type GetUsersIdTasksResponseObject interface {
 	VisitGetUsersIdTasksResponse(w http.ResponseWriter) error
 }
 
-type GetUsersIdTasks200JSONResponse []TaskWithoutUserID
+type GetUsersIdTasks200ResponseHeaders struct {
+	Link        string
+	XTotalCount int64
+}
+
+type GetUsersIdTasks200JSONResponse struct {
+	Body    []TaskWithoutUserID
+	Headers GetUsersIdTasks200ResponseHeaders
+}
 
 func (response GetUsersIdTasks200JSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Link", fmt.Sprint(response.Headers.Link))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
 	w.WriteHeader(200)
 
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUsersIdTasks400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetUsersIdTasks400ApplicationProblemPlusJSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTasks401ApplicationProblemPlusJSONResponse struct {
+	UnauthorizedApplicationProblemPlusJSONResponse
+}
+
+func (response GetUsersIdTasks401ApplicationProblemPlusJSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetUsersIdTasks404Response struct {
+type GetUsersIdTasks403ApplicationProblemPlusJSONResponse struct {
+	ForbiddenApplicationProblemPlusJSONResponse
 }
 
-func (response GetUsersIdTasks404Response) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+func (response GetUsersIdTasks403ApplicationProblemPlusJSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTasks404ApplicationProblemPlusJSONResponse struct {
+	NotFoundApplicationProblemPlusJSONResponse
+}
+
+func (response GetUsersIdTasks404ApplicationProblemPlusJSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(404)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTasks409ApplicationProblemPlusJSONResponse struct {
+	ConflictApplicationProblemPlusJSONResponse
+}
+
+func (response GetUsersIdTasks409ApplicationProblemPlusJSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTasks422ApplicationProblemPlusJSONResponse struct {
+	UnprocessableEntityApplicationProblemPlusJSONResponse
+}
+
+func (response GetUsersIdTasks422ApplicationProblemPlusJSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTasks500ApplicationProblemPlusJSONResponse struct {
+	InternalServerErrorApplicationProblemPlusJSONResponse
+}
+
+func (response GetUsersIdTasks500ApplicationProblemPlusJSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
-	// Получить все задачи
+	// Получить задачи постранично
 	// (GET /tasks)
 	GetTasks(ctx context.Context, request GetTasksRequestObject) (GetTasksResponseObject, error)
 	// Создать новую задачу
 	// (POST /tasks)
 	PostTasks(ctx context.Context, request PostTasksRequestObject) (PostTasksResponseObject, error)
+	// Получить порядок выполнения задач
+	// (GET /tasks/plan)
+	GetTasksPlan(ctx context.Context, request GetTasksPlanRequestObject) (GetTasksPlanResponseObject, error)
+	// Искать по тексту своих задач
+	// (GET /tasks/search)
+	GetTasksSearch(ctx context.Context, request GetTasksSearchRequestObject) (GetTasksSearchResponseObject, error)
 	// Удалить задачу по ID
 	// (DELETE /tasks/{id})
 	DeleteTasksId(ctx context.Context, request DeleteTasksIdRequestObject) (DeleteTasksIdResponseObject, error)
 	// Обновить задачу по ID
 	// (PATCH /tasks/{id})
 	PatchTasksId(ctx context.Context, request PatchTasksIdRequestObject) (PatchTasksIdResponseObject, error)
-	// Получить все задачи пользователя
+	// Получить задачи, которые блокируют задачу
+	// (GET /tasks/{id}/blockers)
+	GetTasksIdBlockers(ctx context.Context, request GetTasksIdBlockersRequestObject) (GetTasksIdBlockersResponseObject, error)
+	// Убрать зависимость задачи от blocker_id
+	// (DELETE /tasks/{id}/blockers/{blocker_id})
+	DeleteTasksIdBlockersBlockerId(ctx context.Context, request DeleteTasksIdBlockersBlockerIdRequestObject) (DeleteTasksIdBlockersBlockerIdResponseObject, error)
+	// Отметить, что задача blocker_id блокирует задачу
+	// (PUT /tasks/{id}/blockers/{blocker_id})
+	PutTasksIdBlockersBlockerId(ctx context.Context, request PutTasksIdBlockersBlockerIdRequestObject) (PutTasksIdBlockersBlockerIdResponseObject, error)
+	// Получить прямые подзадачи задачи
+	// (GET /tasks/{id}/subtasks)
+	GetTasksIdSubtasks(ctx context.Context, request GetTasksIdSubtasksRequestObject) (GetTasksIdSubtasksResponseObject, error)
+	// Получить задачу со всеми подзадачами деревом
+	// (GET /tasks/{id}/tree)
+	GetTasksIdTree(ctx context.Context, request GetTasksIdTreeRequestObject) (GetTasksIdTreeResponseObject, error)
+	// Получить постранично задачи пользователя
 	// (GET /users/{id}/tasks)
 	GetUsersIdTasks(ctx context.Context, request GetUsersIdTasksRequestObject) (GetUsersIdTasksResponseObject, error)
 }
@@ -319,9 +2100,11 @@ type strictHandler struct {
 }
 
 // GetTasks operation middleware
-func (sh *strictHandler) GetTasks(ctx echo.Context) error {
+func (sh *strictHandler) GetTasks(ctx echo.Context, params GetTasksParams) error {
 	var request GetTasksRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetTasks(ctx.Request().Context(), request.(GetTasksRequestObject))
 	}
@@ -370,6 +2153,56 @@ func (sh *strictHandler) PostTasks(ctx echo.Context) error {
 	return nil
 }
 
+// GetTasksPlan operation middleware
+func (sh *strictHandler) GetTasksPlan(ctx echo.Context, params GetTasksPlanParams) error {
+	var request GetTasksPlanRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTasksPlan(ctx.Request().Context(), request.(GetTasksPlanRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTasksPlan")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTasksPlanResponseObject); ok {
+		return validResponse.VisitGetTasksPlanResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetTasksSearch operation middleware
+func (sh *strictHandler) GetTasksSearch(ctx echo.Context, params GetTasksSearchParams) error {
+	var request GetTasksSearchRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTasksSearch(ctx.Request().Context(), request.(GetTasksSearchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTasksSearch")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTasksSearchResponseObject); ok {
+		return validResponse.VisitGetTasksSearchResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // DeleteTasksId operation middleware
 func (sh *strictHandler) DeleteTasksId(ctx echo.Context, id uint) error {
 	var request DeleteTasksIdRequestObject
@@ -426,11 +2259,139 @@ func (sh *strictHandler) PatchTasksId(ctx echo.Context, id uint) error {
 	return nil
 }
 
+// GetTasksIdBlockers operation middleware
+func (sh *strictHandler) GetTasksIdBlockers(ctx echo.Context, id uint) error {
+	var request GetTasksIdBlockersRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTasksIdBlockers(ctx.Request().Context(), request.(GetTasksIdBlockersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTasksIdBlockers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTasksIdBlockersResponseObject); ok {
+		return validResponse.VisitGetTasksIdBlockersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteTasksIdBlockersBlockerId operation middleware
+func (sh *strictHandler) DeleteTasksIdBlockersBlockerId(ctx echo.Context, id uint, blockerId uint) error {
+	var request DeleteTasksIdBlockersBlockerIdRequestObject
+
+	request.Id = id
+	request.BlockerId = blockerId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTasksIdBlockersBlockerId(ctx.Request().Context(), request.(DeleteTasksIdBlockersBlockerIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTasksIdBlockersBlockerId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteTasksIdBlockersBlockerIdResponseObject); ok {
+		return validResponse.VisitDeleteTasksIdBlockersBlockerIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutTasksIdBlockersBlockerId operation middleware
+func (sh *strictHandler) PutTasksIdBlockersBlockerId(ctx echo.Context, id uint, blockerId uint) error {
+	var request PutTasksIdBlockersBlockerIdRequestObject
+
+	request.Id = id
+	request.BlockerId = blockerId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutTasksIdBlockersBlockerId(ctx.Request().Context(), request.(PutTasksIdBlockersBlockerIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutTasksIdBlockersBlockerId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutTasksIdBlockersBlockerIdResponseObject); ok {
+		return validResponse.VisitPutTasksIdBlockersBlockerIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetTasksIdSubtasks operation middleware
+func (sh *strictHandler) GetTasksIdSubtasks(ctx echo.Context, id uint) error {
+	var request GetTasksIdSubtasksRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTasksIdSubtasks(ctx.Request().Context(), request.(GetTasksIdSubtasksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTasksIdSubtasks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTasksIdSubtasksResponseObject); ok {
+		return validResponse.VisitGetTasksIdSubtasksResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetTasksIdTree operation middleware
+func (sh *strictHandler) GetTasksIdTree(ctx echo.Context, id uint) error {
+	var request GetTasksIdTreeRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTasksIdTree(ctx.Request().Context(), request.(GetTasksIdTreeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTasksIdTree")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTasksIdTreeResponseObject); ok {
+		return validResponse.VisitGetTasksIdTreeResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetUsersIdTasks operation middleware
-func (sh *strictHandler) GetUsersIdTasks(ctx echo.Context, id uint) error {
+func (sh *strictHandler) GetUsersIdTasks(ctx echo.Context, id uint, params GetUsersIdTasksParams) error {
 	var request GetUsersIdTasksRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetUsersIdTasks(ctx.Request().Context(), request.(GetUsersIdTasksRequestObject))
@@ -0,0 +1,21 @@
+// Package migrations встраивает SQL-миграции в бинарник,
+// чтобы приложение могло накатывать их само, без внешнего migrate.
+// У каждого диалекта свой каталог, версии миграций в них совпадают
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// FS возвращает миграции для диалекта: postgres или sqlite
+func FS(dialect string) (fs.FS, error) {
+	if _, err := fs.Stat(files, dialect); err != nil {
+		return nil, fmt.Errorf("no migrations for dialect %q", dialect)
+	}
+	return fs.Sub(files, dialect)
+}
@@ -0,0 +1,2 @@
+-- Хеши обратно в пароли не превращаются, возвращаем только имя колонки
+ALTER TABLE users RENAME COLUMN password_hash TO password;
@@ -0,0 +1,10 @@
+-- pgcrypto умеет считать bcrypt-хеши ($2a$), которые понимает golang.org/x/crypto/bcrypt
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+ALTER TABLE users RENAME COLUMN password TO password_hash;
+
+-- Хешируем пароли, которые до сих пор лежат в открытом виде
+UPDATE users
+SET password_hash = crypt(password_hash, gen_salt('bf', 12))
+WHERE password_hash NOT LIKE '$2_$%'
+  AND password_hash NOT LIKE '$argon2id$%';
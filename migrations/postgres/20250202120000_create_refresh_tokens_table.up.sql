@@ -0,0 +1,11 @@
+CREATE TABLE refresh_tokens (
+                       id SERIAL PRIMARY KEY,
+                       user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       token_hash TEXT NOT NULL UNIQUE,
+                       family_id TEXT NOT NULL,
+                       expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                       revoked_at TIMESTAMP WITH TIME ZONE,
+                       created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
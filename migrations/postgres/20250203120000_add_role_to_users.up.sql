@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'member',
+    ADD CONSTRAINT chk_users_role CHECK (role IN ('admin', 'member'));
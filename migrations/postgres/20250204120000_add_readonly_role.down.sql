@@ -0,0 +1,5 @@
+UPDATE users SET role = 'member' WHERE role = 'readonly';
+
+ALTER TABLE users
+DROP CONSTRAINT IF EXISTS chk_users_role,
+    ADD CONSTRAINT chk_users_role CHECK (role IN ('admin', 'member'));
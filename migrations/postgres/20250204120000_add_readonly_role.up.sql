@@ -0,0 +1,3 @@
+ALTER TABLE users
+DROP CONSTRAINT IF EXISTS chk_users_role,
+    ADD CONSTRAINT chk_users_role CHECK (role IN ('admin', 'member', 'readonly'));
@@ -0,0 +1,40 @@
+-- gorm.Model пишет время с часовым поясом, как в users. Старые значения считаем UTC
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMP WITH TIME ZONE USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMP WITH TIME ZONE USING updated_at AT TIME ZONE 'UTC',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP WITH TIME ZONE USING deleted_at AT TIME ZONE 'UTC';
+
+UPDATE users SET created_at = NOW() WHERE created_at IS NULL;
+UPDATE users SET updated_at = created_at WHERE updated_at IS NULL;
+UPDATE refresh_tokens SET created_at = NOW() WHERE created_at IS NULL;
+
+ALTER TABLE users
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET NOT NULL;
+
+ALTER TABLE refresh_tokens
+    ALTER COLUMN created_at SET NOT NULL;
+
+-- Задачи без владельца с тех пор, как задачи видны только владельцу, недоступны через API.
+-- Удалять их молча нельзя: миграция останавливается, а решает, кому их отдать, человек.
+-- Файл выполняется одним запросом, поэтому до ошибки ничего не применится, останется
+-- только снять dirty-флаг через force на предыдущую версию
+DO $$
+DECLARE
+    orphaned BIGINT;
+BEGIN
+    SELECT COUNT(*) INTO orphaned FROM tasks WHERE user_id IS NULL;
+    IF orphaned > 0 THEN
+        RAISE EXCEPTION 'found % tasks without user_id', orphaned
+            USING HINT = 'assign them to a user (UPDATE tasks SET user_id = ...) or delete them, then run: app migrate force 20250204120000 && app migrate up';
+    END IF;
+END $$;
+
+ALTER TABLE tasks
+    ALTER COLUMN user_id SET NOT NULL,
+    ALTER COLUMN is_done SET NOT NULL;
+
+-- Имена совпадают с теми, что gorm выводит из тегов index
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks (user_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
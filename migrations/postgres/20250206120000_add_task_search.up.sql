@@ -0,0 +1,15 @@
+-- Конфигурация поиска для задач на русском и английском. Копия russian уже
+-- отдаёт латиницу английскому стеммеру, но пусть это будет записано явно,
+-- а не зависит от настроек по умолчанию
+CREATE TEXT SEARCH CONFIGURATION tasks_multilingual (COPY = pg_catalog.russian);
+ALTER TEXT SEARCH CONFIGURATION tasks_multilingual
+    ALTER MAPPING FOR asciiword, asciihword, hword_asciipart WITH english_stem;
+ALTER TEXT SEARCH CONFIGURATION tasks_multilingual
+    ALTER MAPPING FOR word, hword, hword_part WITH russian_stem;
+
+-- Вектор считает сама база при каждой записи, приложение его не трогает
+ALTER TABLE tasks
+    ADD COLUMN search_vector TSVECTOR
+        GENERATED ALWAYS AS (to_tsvector('tasks_multilingual'::regconfig, task)) STORED;
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
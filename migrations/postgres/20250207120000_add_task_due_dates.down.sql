@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_remind_at;
+DROP INDEX IF EXISTS idx_tasks_due_at;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS reminded_at,
+    DROP COLUMN IF EXISTS remind_at,
+    DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,10 @@
+-- Срок и напоминание по задаче, оба необязательные. reminded_at ставит
+-- планировщик напоминаний, когда напоминание отправлено
+ALTER TABLE tasks
+    ADD COLUMN due_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN remind_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reminded_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_tasks_due_at ON tasks (due_at);
+-- Планировщику нужны только неотправленные напоминания, их немного
+CREATE INDEX idx_tasks_remind_at ON tasks (remind_at) WHERE reminded_at IS NULL AND deleted_at IS NULL;
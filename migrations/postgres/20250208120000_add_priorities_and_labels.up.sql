@@ -0,0 +1,28 @@
+-- Приоритет от P0 (горит) до P3 (когда-нибудь). Старые задачи становятся P2, обычными
+ALTER TABLE tasks
+    ADD COLUMN priority SMALLINT NOT NULL DEFAULT 2 CHECK (priority BETWEEN 0 AND 3);
+
+-- Для сортировки списка задач пользователя по приоритету
+CREATE INDEX idx_tasks_user_id_priority ON tasks (user_id, priority, id);
+
+-- Метки у каждого пользователя свои. Удаляются сразу, без deleted_at:
+-- иначе удалённая метка занимала бы имя
+CREATE TABLE labels (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_labels_user_id_name ON labels (user_id, name);
+
+CREATE TABLE task_labels (
+    task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    label_id INTEGER NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+);
+
+-- Первичный ключ начинается с task_id, для фильтра по метке нужен свой индекс
+CREATE INDEX idx_task_labels_label_id ON task_labels (label_id);
@@ -0,0 +1,6 @@
+-- Подзадачи: parent_id ссылается на задачу-родителя, у корневых задач он пустой.
+-- Глубину дерева и отсутствие циклов проверяет приложение
+ALTER TABLE tasks
+    ADD COLUMN parent_id INTEGER REFERENCES tasks (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id);
@@ -0,0 +1,14 @@
+-- Оценка задачи в минутах. По оценкам считается критический путь
+ALTER TABLE tasks
+    ADD COLUMN estimate_minutes INTEGER CHECK (estimate_minutes >= 0);
+
+-- Зависимости между задачами: blocker_id блокирует blocked_id. Циклов в графе
+-- быть не должно, это проверяет приложение перед вставкой
+CREATE TABLE task_dependencies (
+    blocker_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blocked_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocked_id ON task_dependencies (blocked_id);
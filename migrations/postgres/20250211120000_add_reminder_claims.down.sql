@@ -0,0 +1,3 @@
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS remind_attempts,
+    DROP COLUMN IF EXISTS remind_claimed_until;
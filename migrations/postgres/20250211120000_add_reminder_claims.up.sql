@@ -0,0 +1,6 @@
+-- Планировщик сначала забирает напоминание до remind_claimed_until и только потом
+-- отправляет. Не отправилось или процесс упал - по истечении срока его заберут снова.
+-- remind_attempts ограничивает число таких попыток
+ALTER TABLE tasks
+    ADD COLUMN remind_claimed_until TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN remind_attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Откат поиска по задачам: в SQLite откатывать нечего
+SELECT 1;
@@ -0,0 +1,4 @@
+-- В SQLite нет tsvector: поиск по задачам идёт через LIKE-подобный запасной
+-- вариант в репозитории, схема не меняется. Миграция нужна, чтобы версии
+-- совпадали с postgres
+SELECT 1;
@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN due_at DATETIME;
+ALTER TABLE tasks ADD COLUMN remind_at DATETIME;
+ALTER TABLE tasks ADD COLUMN reminded_at DATETIME;
+
+CREATE INDEX idx_tasks_due_at ON tasks (due_at);
+CREATE INDEX idx_tasks_remind_at ON tasks (remind_at) WHERE reminded_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_labels;
+DROP TABLE IF EXISTS labels;
+DROP INDEX IF EXISTS idx_tasks_user_id_priority;
+ALTER TABLE tasks DROP COLUMN priority;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_dependencies;
+ALTER TABLE tasks DROP COLUMN estimate_minutes;
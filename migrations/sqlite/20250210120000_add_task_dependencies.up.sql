@@ -0,0 +1,10 @@
+ALTER TABLE tasks ADD COLUMN estimate_minutes INTEGER CHECK (estimate_minutes >= 0);
+
+CREATE TABLE task_dependencies (
+    blocker_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blocked_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocked_id ON task_dependencies (blocked_id);
@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN remind_attempts;
+ALTER TABLE tasks DROP COLUMN remind_claimed_until;
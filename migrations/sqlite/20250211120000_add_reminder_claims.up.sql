@@ -0,0 +1,2 @@
+ALTER TABLE tasks ADD COLUMN remind_claimed_until DATETIME;
+ALTER TABLE tasks ADD COLUMN remind_attempts INTEGER NOT NULL DEFAULT 0;